@@ -0,0 +1,64 @@
+package zeus
+
+import (
+	"reflect"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// Alias makes the type of sample from resolve to the type of sample to, sharing its value.
+// Unlike As, the alias can be declared at any time, for types provided elsewhere, and takes precedence
+// over the factories and bindings of from. The samples are values of the types, or pointers to them for interfaces.
+// Returns an errs.MismatchedValueError if to cannot be used as from, or a CyclicDependencyError,
+// without recording the alias, if it leads back to from.
+//
+// Example:
+//
+//	c.Provide(NewZapLogger)
+//	c.Alias(new(Logger), (*ZapLogger)(nil))
+func (c *Container) Alias(from, to interface{}) error {
+	source, target := sampleType(from), sampleType(to)
+
+	if source == nil || target == nil {
+		return errs.NilValueError{}
+	}
+
+	if !target.AssignableTo(source) {
+		return errs.MismatchedValueError{TypeName: source.String(), ValueType: target.String()}
+	}
+
+	stale, err := c.stale([]reflect.Type{source})
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	previous, replaced := c.aliases[source]
+	c.aliases[source] = target
+	c.mu.Unlock()
+
+	if err := c.detectCycle(source); err != nil {
+		c.mu.Lock()
+		if replaced {
+			c.aliases[source] = previous
+		} else {
+			delete(c.aliases, source)
+		}
+		c.mu.Unlock()
+
+		return err
+	}
+
+	c.mu.Lock()
+	c.invalidate(stale)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// aliasOf returns the type the given type is aliased to, if any. The caller must hold the lock.
+func (c *Container) aliasOf(t reflect.Type) (reflect.Type, bool) {
+	target, exists := c.aliases[t]
+	return target, exists
+}
@@ -0,0 +1,99 @@
+package zeus
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestAlias(t *testing.T) {
+	t.Parallel()
+
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+	t.Run("Resolves the shared instance of the target", func(t *testing.T) {
+		c := New()
+		c.Provide(func() *strings.Builder { return &strings.Builder{} })
+
+		err := c.Alias(new(fmt.Stringer), (*strings.Builder)(nil))
+		assert.NilError(t, err)
+
+		var stringer fmt.Stringer
+		var builder *strings.Builder
+		err = c.Run(func(s fmt.Stringer, b *strings.Builder) {
+			stringer, builder = s, b
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, stringer.(*strings.Builder), builder)
+	})
+
+	t.Run("Takes precedence over the factory", func(t *testing.T) {
+		c := New()
+		c.Provide(func() fmt.Stringer { return &net.IPNet{} })
+		c.Provide(func() *strings.Builder { return &strings.Builder{} })
+		c.Alias(new(fmt.Stringer), (*strings.Builder)(nil))
+
+		val, err := c.resolve(stringerType, nil)
+		assert.NilError(t, err)
+
+		_, ok := val.Interface().(*strings.Builder)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("Chained aliases", func(t *testing.T) {
+		type Builder interface {
+			fmt.Stringer
+			WriteString(string) (int, error)
+		}
+
+		c := New()
+		c.Provide(func() *strings.Builder { return &strings.Builder{} })
+		assert.NilError(t, c.Alias(new(fmt.Stringer), new(Builder)))
+		assert.NilError(t, c.Alias(new(Builder), (*strings.Builder)(nil)))
+
+		val, err := c.resolve(stringerType, nil)
+		assert.NilError(t, err)
+
+		_, ok := val.Interface().(*strings.Builder)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("Alias cycle", func(t *testing.T) {
+		type Named interface{ String() string }
+
+		c := New()
+		assert.NilError(t, c.Alias(new(fmt.Stringer), new(Named)))
+
+		got := c.Alias(new(Named), new(fmt.Stringer))
+		assert.ErrorIs(t, got, errs.CyclicDependencyError{TypeName: "Named"})
+
+		_, aliased := c.aliasOf(reflect.TypeOf((*Named)(nil)).Elem())
+		assert.Assert(t, !aliased)
+
+		got = c.Alias(0, 0)
+		assert.ErrorIs(t, got, errs.CyclicDependencyError{TypeName: "int"})
+	})
+
+	t.Run("Target cannot be used as the alias", func(t *testing.T) {
+		c := New()
+		got := c.Alias(new(fmt.Stringer), 0)
+		expected := errs.MismatchedValueError{TypeName: "fmt.Stringer", ValueType: "int"}
+
+		assert.ErrorIs(t, got, expected)
+	})
+
+	t.Run("Listed in the graph", func(t *testing.T) {
+		c := New()
+		c.Provide(func() *strings.Builder { return &strings.Builder{} })
+		c.Alias(new(fmt.Stringer), (*strings.Builder)(nil))
+
+		graph := c.Graph()
+		assert.DeepEqual(t, typeNames(graph[stringerType]), []string{"*strings.Builder"})
+		assert.NilError(t, c.Validate())
+	})
+}
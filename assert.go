@@ -0,0 +1,76 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// AssertResolvable fails the test if any dependency of fn cannot be resolved from the container, without running fn.
+// Like ValidationIssues, it inspects the static graph and builds nothing, reporting every missing, ambiguous
+// or cyclic dependency the function needs, directly or not, in a single message.
+//
+// Example:
+//
+//	func TestWiring(t *testing.T) {
+//	    zeus.AssertResolvable(t, newContainer(), serve)
+//	}
+func AssertResolvable(t testing.TB, c *Container, fn interface{}) {
+	t.Helper()
+
+	fnType := reflect.TypeOf(fn)
+
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		t.Errorf("%s", errs.NotAFunctionError{})
+		return
+	}
+
+	issues := c.functionIssues(fnType)
+
+	if len(issues) == 0 {
+		return
+	}
+
+	messages := make([]string, len(issues))
+
+	for i, issue := range issues {
+		messages[i] = "  " + issue.Message
+	}
+
+	t.Errorf("dependencies of %s are not resolvable:\n%s", fnType, strings.Join(messages, "\n"))
+}
+
+// AssertAcyclic fails the test if the dependency graph of the container has a cycle, reporting the path of each one.
+// Like ValidationIssues, from which it takes the cycles, it inspects the static graph and builds nothing.
+// Missing and ambiguous dependencies are left to Validate and AssertResolvable.
+//
+// Example:
+//
+//	func TestWiring(t *testing.T) {
+//	    zeus.AssertAcyclic(t, newContainer())
+//	}
+func AssertAcyclic(t testing.TB, c *Container) {
+	t.Helper()
+
+	paths := []string{}
+
+	for _, issue := range c.ValidationIssues() {
+		if issue.Kind == IssueCyclic {
+			paths = append(paths, "  "+cyclePath(issue.Types))
+		}
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	t.Errorf("dependency graph has cycles:\n%s", strings.Join(paths, "\n"))
+}
+
+// cyclePath formats the types of a cycle as a path leading back to its first type.
+func cyclePath(cycle []reflect.Type) string {
+	return strings.Join(typeNames(append(slices.Clone(cycle), cycle[0])), " -> ")
+}
@@ -0,0 +1,116 @@
+package zeus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// fakeTB is a testing.TB recording the failures reported to it.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertResolvable(t *testing.T) {
+	t.Parallel()
+
+	type Config struct{ DSN string }
+	type Database struct{}
+	type Server struct{}
+
+	t.Run("Passes for satisfiable dependencies", func(t *testing.T) {
+		c := New()
+		c.Provide(func() Config { return Config{} })
+		c.Provide(func(cfg Config) *Database { return &Database{} })
+
+		tb := &fakeTB{}
+		AssertResolvable(tb, c, func(db *Database, h Hooks, p Provider[*Server]) {})
+
+		assert.Equal(t, len(tb.failures), 0)
+	})
+
+	t.Run("Fails for missing dependencies", func(t *testing.T) {
+		c := New()
+		c.Provide(func(cfg Config) *Database { return &Database{} })
+
+		tb := &fakeTB{}
+		AssertResolvable(tb, c, func(db *Database, s *Server) {})
+
+		assert.Equal(t, len(tb.failures), 1)
+		assert.Assert(t, strings.Contains(tb.failures[0], "failed to resolve dependency for type Config, needed by *zeus.Database"), tb.failures[0])
+		assert.Assert(t, strings.Contains(tb.failures[0], "needed by the function"), tb.failures[0])
+	})
+
+	t.Run("Fails for cycles", func(t *testing.T) {
+		c := New()
+		c.Provide(func(db *Database) Config { return Config{} })
+		c.Provide(func(cfg Config) *Database { return &Database{} })
+
+		tb := &fakeTB{}
+		AssertResolvable(tb, c, func(db *Database) {})
+
+		assert.Equal(t, len(tb.failures), 1)
+		assert.Assert(t, strings.Contains(tb.failures[0], "cyclic dependency detected"), tb.failures[0])
+	})
+
+	t.Run("Does not run the function", func(t *testing.T) {
+		c := New()
+		calls := 0
+		c.Provide(func() Config { calls++; return Config{} })
+
+		tb := &fakeTB{}
+		AssertResolvable(tb, c, func(cfg Config) { calls++ })
+
+		assert.Equal(t, len(tb.failures), 0)
+		assert.Equal(t, calls, 0)
+	})
+
+	t.Run("Not a function", func(t *testing.T) {
+		tb := &fakeTB{}
+		AssertResolvable(tb, New(), "fn")
+
+		assert.DeepEqual(t, tb.failures, []string{"provided object is not a function"})
+	})
+}
+
+func TestAssertAcyclic(t *testing.T) {
+	t.Parallel()
+
+	type Config struct{ DSN string }
+	type Database struct{}
+
+	t.Run("Passes for an acyclic graph", func(t *testing.T) {
+		c := New()
+		c.Provide(func() Config { return Config{} })
+		c.Provide(func(cfg Config) *Database { return &Database{} })
+		c.Provide(func(db *Database, s *strings.Builder) string { return "" })
+
+		tb := &fakeTB{}
+		AssertAcyclic(tb, c)
+
+		assert.Equal(t, len(tb.failures), 0)
+	})
+
+	t.Run("Fails with the path of a cycle", func(t *testing.T) {
+		c := New()
+		c.Provide(func(db *Database) Config { return Config{} })
+		c.Provide(func(cfg Config) *Database { return &Database{} })
+		c.Provide(func(db *Database) string { return "" })
+
+		tb := &fakeTB{}
+		AssertAcyclic(tb, c)
+
+		assert.Equal(t, len(tb.failures), 1)
+		assert.Assert(t, strings.Contains(tb.failures[0], "dependency graph has cycles"), tb.failures[0])
+		assert.Assert(t, strings.Contains(tb.failures[0], "*zeus.Database -> zeus.Config -> *zeus.Database"), tb.failures[0])
+	})
+}
@@ -1,36 +1,140 @@
 package zeus
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"reflect"
+	"runtime"
 	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/otoru/zeus/errs"
 	"github.com/otoru/zeus/hooks"
 )
 
+var (
+	hooksType    = reflect.TypeOf((*hooks.Hooks)(nil)).Elem()
+	registryType = reflect.TypeOf((*Registry)(nil)).Elem()
+	loggerType   = reflect.TypeOf((*slog.Logger)(nil))
+	injectorType = reflect.TypeOf((*injector)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
 // Container holds the registered factories for dependency resolution.
 type Container struct {
-	providers map[reflect.Type]reflect.Value
-	instances map[reflect.Type]reflect.Value
-	mu        sync.RWMutex
-	hooks     Hooks
+	providers           map[reflect.Type]reflect.Value
+	registered          []reflect.Type
+	instances           map[reflect.Type]reflect.Value
+	store               InstanceStore
+	resolver            FallbackResolver
+	keyed               map[reflect.Type]map[string]reflect.Value
+	decorators          map[reflect.Type][]reflect.Value
+	decoratorPriorities map[reflect.Type][]int
+	bindings            map[reflect.Type][]reflect.Type
+	aliases             map[reflect.Type]reflect.Type
+	converters          map[reflect.Type][]reflect.Value
+	tuples              map[reflect.Type][]reflect.Value
+	ordering            map[reflect.Type][]reflect.Type
+	groups              map[string][]*groupMember
+	cleanups            map[reflect.Type][]reflect.Value
+	optionals           map[reflect.Type][]reflect.Type
+	timeouts            map[reflect.Type]time.Duration
+	flags               map[reflect.Type]string
+	supplied            map[reflect.Type]bool
+	disabled            map[reflect.Type]bool
+	nilDefaults         map[reflect.Type]bool
+	building            map[reflect.Type]*construction
+	ready               []func()
+	live                *atomic.Int32
+	startup             *atomic.Int64
+	events              chan Event
+	tracers             []*tracer
+	warnings            *warningLog
+	timings             *timingLog
+	mu                  sync.RWMutex
+	records             []hookRecord
+	lifecycles          []lifecycle
+	sequence            uint64
+	started             bool
+	closed              bool
+	grace               time.Duration
+	concurrency         int
+	capacity            int
+	maxParams           int
+	recent              []reflect.Type
+	logger              *slog.Logger
+	prototype           bool
+	fallback            bool
+	collecting          bool
+	strict              bool
+	logPlan             bool
+	frozen              bool
+	copying             bool
+	requireError        bool
+	late                LatePolicy
+	running             atomic.Int32
+	recovering          bool
 }
 
 // New initializes and returns a new instance of the Container.
+// Options can be passed to customize the container behavior.
 //
 // Example:
 //
 //	c := zeus.New()
-func New() *Container {
-	hooks := new(hooks.LifecycleHooks)
+//	p := zeus.New(zeus.Prototype())
+func New(options ...Option) *Container {
 	providers := make(map[reflect.Type]reflect.Value)
 	instances := make(map[reflect.Type]reflect.Value)
+	keyed := make(map[reflect.Type]map[string]reflect.Value)
+	decorators := make(map[reflect.Type][]reflect.Value)
+	decoratorPriorities := make(map[reflect.Type][]int)
+	bindings := make(map[reflect.Type][]reflect.Type)
+	aliases := make(map[reflect.Type]reflect.Type)
+	converters := make(map[reflect.Type][]reflect.Value)
+	tuples := make(map[reflect.Type][]reflect.Value)
+	ordering := make(map[reflect.Type][]reflect.Type)
+	groups := make(map[string][]*groupMember)
+	cleanups := make(map[reflect.Type][]reflect.Value)
+	optionals := make(map[reflect.Type][]reflect.Type)
+	timeouts := make(map[reflect.Type]time.Duration)
+	flags := make(map[reflect.Type]string)
+	supplied := make(map[reflect.Type]bool)
+	disabled := make(map[reflect.Type]bool)
+	building := make(map[reflect.Type]*construction)
 
 	container := new(Container)
-	container.hooks = hooks
 	container.providers = providers
 	container.instances = instances
+	container.keyed = keyed
+	container.decorators = decorators
+	container.decoratorPriorities = decoratorPriorities
+	container.bindings = bindings
+	container.aliases = aliases
+	container.converters = converters
+	container.tuples = tuples
+	container.ordering = ordering
+	container.groups = groups
+	container.cleanups = cleanups
+	container.optionals = optionals
+	container.timeouts = timeouts
+	container.flags = flags
+	container.supplied = supplied
+	container.disabled = disabled
+	container.grace = defaultGracePeriod
+	container.logger = slog.Default()
+	container.warnings = new(warningLog)
+	container.live = new(atomic.Int32)
+	container.startup = new(atomic.Int64)
+	container.building = building
+
+	for _, option := range options {
+		option(container)
+	}
 
 	return container
 }
@@ -38,193 +142,1924 @@ func New() *Container {
 // resolve attempts to resolve a dependency of the given type.
 // It checks for cyclic dependencies and ensures that all dependencies can be resolved.
 // Returns the resolved value and any error encountered during resolution.
-func (c *Container) resolve(t reflect.Type, stack []reflect.Type) (reflect.Value, error) {
-	if slices.Contains(stack, t) {
+func (c *Container) resolve(t reflect.Type, stack *typeStack) (reflect.Value, error) {
+	if c.isClosed() {
+		return reflect.Value{}, errs.ContainerStoppedError{TypeName: t.Name()}
+	}
+
+	if stack.contains(t) {
 		return reflect.Value{}, errs.CyclicDependencyError{TypeName: t.Name()}
 	}
 
+	if t.Implements(injectorType) {
+		return reflect.Zero(t).Interface().(injector).inject(c, stack), nil
+	}
+
+	if c.isDisabled(t) {
+		return reflect.Value{}, errs.DependencyResolutionError{TypeName: t.Name()}
+	}
+
+	if stack == nil {
+		stack = newTypeStack(nil)
+	}
+
+	if t == runCacheType {
+		return reflect.ValueOf(stack.runCache()), nil
+	}
+
+	if c.prototype {
+		stack.push(t)
+		defer stack.pop()
+
+		return c.build(t, stack)
+	}
+
 	c.mu.RLock()
-	instance, hasInstance := c.instances[t]
-	provider, hasProvider := c.providers[t]
+	instance, hasInstance := c.cached(t)
 	c.mu.RUnlock()
 
 	if hasInstance {
-		return instance, nil
+		c.touch(t)
+		c.trace(TraceEntry{Type: t, Cached: true})
+		return c.isolate(instance), nil
 	}
 
-	if !hasProvider {
-		return reflect.Value{}, errs.DependencyResolutionError{TypeName: t.Name()}
+	build, owner := c.claim(t)
+
+	if !owner {
+		<-build.done
+		c.trace(TraceEntry{Type: t, Cached: true, Err: build.err})
+		return c.isolate(build.value), build.err
 	}
 
-	providerType := provider.Type()
-	dependencies := make([]reflect.Value, providerType.NumIn())
+	stack.push(t)
+	defer stack.pop()
 
-	for i := range dependencies {
-		argType := providerType.In(i)
+	defer func() {
+		if value := recover(); value != nil {
+			build.err = errs.PanicError{Source: "factory for type " + t.String(), Value: value}
+			c.release(t, build)
+			panic(value)
+		}
+	}()
 
-		if argType.Implements(reflect.TypeOf((*Hooks)(nil)).Elem()) {
-			dependencies[i] = reflect.ValueOf(c.hooks)
-			continue
+	build.value, build.err = c.build(t, stack)
+	c.release(t, build)
+
+	return c.isolate(build.value), build.err
+}
+
+// isolate returns a shallow copy of the given value when the container copies on resolve and the value is not a reference,
+// so that consumers never share the storage of a cached value. Other values are returned as is.
+func (c *Container) isolate(value reflect.Value) reflect.Value {
+	if !c.copying || !value.IsValid() {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return value
+	}
+
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+
+	return copied
+}
+
+// construction tracks the build of a type, so concurrent resolutions of it wait for a single factory call.
+type construction struct {
+	done  chan struct{}
+	value reflect.Value
+	err   error
+}
+
+// claim registers the caller as the builder of the given type and reports whether it owns the construction.
+// If the type is already cached or being built by someone else, the returned construction must be waited on instead.
+func (c *Container) claim(t reflect.Type) (*construction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if instance, exists := c.cached(t); exists {
+		build := &construction{done: make(chan struct{}), value: instance}
+		close(build.done)
+		return build, false
+	}
+
+	if build, exists := c.building[t]; exists {
+		return build, false
+	}
+
+	build := &construction{done: make(chan struct{})}
+	c.building[t] = build
+
+	return build, true
+}
+
+// release caches the outcome of a successful construction and wakes up everyone waiting on it.
+func (c *Container) release(t reflect.Type, build *construction) {
+	c.mu.Lock()
+	var evicted *hooks.LifecycleHooks
+
+	if build.err == nil {
+		evicted = c.cache(t, build.value)
+	}
+
+	delete(c.building, t)
+	c.mu.Unlock()
+
+	close(build.done)
+
+	if evicted != nil {
+		if err := evicted.Stop(); err != nil {
+			c.logger.Warn("stop hooks of an evicted instance failed", "error", err)
 		}
+	}
+}
 
-		argValue, err := c.resolve(argType, append(stack, t))
+// build constructs a new value of the given type and applies its decorators.
+// The stack must already include the type being built.
+// Hooks registered by the factories involved are staged and only kept if the whole construction succeeds.
+func (c *Container) build(t reflect.Type, stack *typeStack) (result reflect.Value, err error) {
+	c.emit(t, PhaseConstructing, nil)
+	defer func(started time.Time) {
+		c.trace(TraceEntry{Type: t, Duration: time.Since(started), Err: err})
+		c.emit(t, PhaseConstructed, err)
+	}(time.Now())
 
-		if err != nil {
+	for _, predecessor := range c.predecessors(t) {
+		if _, err := c.resolve(predecessor, stack); err != nil {
 			return reflect.Value{}, err
 		}
+	}
 
-		dependencies[i] = argValue
+	stage := new(hooks.LifecycleHooks)
+	result, err = c.construct(t, stack, stage)
+
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	results := provider.Call(dependencies)
+	result, err = c.decorate(t, result, stack, stage)
 
-	if len(results) == 2 && !results[1].IsNil() {
-		return reflect.Value{}, results[1].Interface().(error)
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	c.instances[t] = results[0]
+	c.registerCleanups(t, result, stage)
+	c.record(t, stage)
 
-	return results[0], nil
+	return result, nil
 }
 
-// Provide registers a factory function for dependency resolution.
-// It ensures that the factory is a function, has a valid return type, and checks for duplicate factories.
-// Returns an error if any of these conditions are not met.
-//
-// Example:
-//
-//	c := zeus.New()
-//	c.Provide(func() int { return 42 })
-func (c *Container) Provide(factories ...interface{}) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// registerCleanups adds a stop hook to the stage for each cleanup registered for the type with WithCleanup,
+// each receiving the given value.
+func (c *Container) registerCleanups(t reflect.Type, value reflect.Value, stage *hooks.LifecycleHooks) {
+	c.mu.RLock()
+	cleanups := c.cleanups[t]
+	c.mu.RUnlock()
 
-	for _, factory := range factories {
-		factoryType := reflect.TypeOf(factory)
+	for _, cleanup := range cleanups {
+		cleanup := cleanup
+
+		stage.OnStopContext(func(context.Context) error {
+			results, err := c.call(cleanup, []reflect.Value{value})
+
+			if err != nil {
+				return err
+			}
+
+			if len(results) == 1 && !results[0].IsNil() {
+				return results[0].Interface().(error)
+			}
 
-		if factoryType.Kind() != reflect.Func {
-			return errs.NotAFunctionError{}
+			return nil
+		})
+	}
+}
+
+// construct builds a new value of the given type from its registered factories.
+// The stack must already include the type being constructed, and hooks are registered on the given stage.
+func (c *Container) construct(t reflect.Type, stack *typeStack, stage *hooks.LifecycleHooks) (reflect.Value, error) {
+	c.mu.RLock()
+	target, aliased := c.aliasOf(t)
+	provider, hasProvider := c.providers[t]
+	c.mu.RUnlock()
+
+	if aliased {
+		return c.buildBinding(t, target, stack)
+	}
+
+	if hasProvider {
+		enabled, err := c.flagEnabled(t, stack)
+
+		if err != nil {
+			return reflect.Value{}, err
 		}
 
-		if numOut := factoryType.NumOut(); numOut < 1 || numOut > 2 {
-			return errs.InvalidFactoryReturnError{NumReturns: numOut}
+		hasProvider = enabled
+	}
+
+	if hasProvider {
+		// Hooks are staged apart, so that a factory skipping its type leaves none behind.
+		attempt := new(hooks.LifecycleHooks)
+		var value reflect.Value
+		var err error
+
+		if outputs := factoryOutputs(provider.Type()); len(outputs) > 1 {
+			value, err = c.buildTuple(t, provider, outputs, stack, attempt)
+		} else if values, invokeErr := c.invokeWithin(t, provider, stack, attempt); invokeErr != nil {
+			err = invokeErr
+		} else {
+			value = values[0]
 		}
 
-		if factoryType.NumOut() == 2 {
-			errorType := reflect.TypeOf((*error)(nil)).Elem()
-			if !factoryType.Out(1).Implements(errorType) {
-				return errs.UnexpectedReturnTypeError{TypeName: factoryType.Out(1).Name()}
-			}
+		if !errors.Is(err, errs.ErrSkip) {
+			stage.Append(attempt)
+			return value, err
 		}
+	}
 
-		serviceType := factoryType.Out(0)
+	if keyed, ok := c.keyedProviders(t); ok {
+		return c.buildKeyed(t, keyed, stack, stage)
+	}
+
+	c.mu.RLock()
+	bindings := slices.DeleteFunc(slices.Clone(c.bindings[t]), func(concrete reflect.Type) bool {
+		return c.disabled[concrete]
+	})
+	converters := c.converters[t]
+	implementations := c.implementations(t)
+	collected := c.collected(t)
+	nilDefault := c.nilDefaults[t]
+	c.mu.RUnlock()
 
-		if _, exists := c.providers[serviceType]; exists {
-			return errs.FactoryAlreadyProvidedError{TypeName: serviceType.Name()}
+	switch {
+	case len(bindings) == 1:
+		return c.buildBinding(t, bindings[0], stack)
+	case len(bindings) > 1:
+		return reflect.Value{}, ambiguous(t, bindings)
+	case len(converters) == 1:
+		return c.invoke(converters[0], stack, nil, stage)
+	case len(converters) > 1:
+		sources := make([]reflect.Type, len(converters))
+
+		for i, converter := range converters {
+			sources[i] = converter.Type().In(0)
 		}
 
-		c.providers[serviceType] = reflect.ValueOf(factory)
+		return reflect.Value{}, ambiguous(t, sources)
+	case len(implementations) == 1:
+		return c.buildBinding(t, implementations[0], stack)
+	case len(implementations) > 1:
+		return reflect.Value{}, ambiguous(t, implementations)
+	case collected != nil:
+		return c.buildCollected(t, collected, stack)
+	case nilDefault:
+		return reflect.Zero(t), nil
 	}
 
-	return nil
+	if value, ok, err := c.resolveFallback(t, stack); ok || err != nil {
+		return value, err
+	}
+
+	if provided, ok := c.providedAs(t); ok {
+		return reflect.Value{}, errs.ProvidedAsError{TypeName: t.String(), ProvidedName: provided.String()}
+	}
+
+	return reflect.Value{}, errs.DependencyResolutionError{TypeName: t.Name()}
 }
 
-// Run executes the provided function by resolving and injecting its dependencies.
-// It ensures that the function has a valid signature and that all dependencies can be resolved.
-// Returns an error if the function signature is invalid or if dependencies cannot be resolved.
-//
-// Example:
-//
-//	c := zeus.New()
-//	c.Provide(func() int { return 42 })
-//	c.Run(func(i int) {
-//	    fmt.Println(i) // Outputs: 42
-//	})
-func (c *Container) Run(fn interface{}) error {
-	errorSet := &errs.ErrorSet{}
+// providedAs returns the provided type a consumer of the given type most likely meant, in strict mode:
+// the same type with a different pointer-ness, or else the first registered interface the type implements.
+func (c *Container) providedAs(t reflect.Type) (reflect.Type, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	fnType := reflect.TypeOf(fn)
+	if !c.strict {
+		return nil, false
+	}
 
-	if fnType.Kind() != reflect.Func {
-		return errs.NotAFunctionError{}
+	if t.Kind() == reflect.Pointer {
+		if _, ok := c.providers[t.Elem()]; ok {
+			return t.Elem(), true
+		}
+	} else if _, ok := c.providers[reflect.PointerTo(t)]; ok {
+		return reflect.PointerTo(t), true
 	}
 
-	if numOut := fnType.NumOut(); numOut > 1 {
-		return errs.InvalidFactoryReturnError{NumReturns: numOut}
+	if t.Kind() == reflect.Interface {
+		return nil, false
 	}
 
-	if fnType.NumOut() == 1 && fnType.Out(0).Name() != "error" {
-		return errs.UnexpectedReturnTypeError{TypeName: fnType.Out(0).Name()}
+	for _, registered := range c.registered {
+		if registered.Kind() == reflect.Interface && t.Implements(registered) {
+			return registered, true
+		}
 	}
 
-	dependencies := make([]reflect.Value, fnType.NumIn())
+	return nil, false
+}
 
-	for i := range dependencies {
-		argType := fnType.In(i)
-		argValue, err := c.resolve(argType, nil)
+// implementations returns the provided types implementing the given interface, sorted by name,
+// when the container falls back to them with InterfaceFallback and nothing is bound to the interface.
+// Disabled types are skipped. The caller must hold the lock and handle a factory registered for the interface itself.
+func (c *Container) implementations(t reflect.Type) []reflect.Type {
+	if !c.fallback || t.Kind() != reflect.Interface || len(c.bindings[t]) > 0 || len(c.converters[t]) > 0 {
+		return nil
+	}
+
+	implementations := []reflect.Type{}
+
+	for provided := range c.providers {
+		if provided != t && !c.disabled[provided] && provided.Implements(t) {
+			implementations = append(implementations, provided)
+		}
+	}
+
+	return sortTypes(implementations)
+}
+
+// collected returns the provided types implementing the element interface of the given slice type, in registration order,
+// when the container collects them with AutoCollectInterfaces and nothing is bound to the slice.
+// Disabled types are skipped. Returns nil if the slice is not collected.
+// The caller must hold the lock and handle a factory registered for the slice itself.
+func (c *Container) collected(t reflect.Type) []reflect.Type {
+	if !c.collecting || t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Interface {
+		return nil
+	}
+
+	if len(c.bindings[t]) > 0 || len(c.converters[t]) > 0 {
+		return nil
+	}
+
+	collected := []reflect.Type{}
+
+	for _, provided := range c.registered {
+		if provided != t.Elem() && !c.disabled[provided] && provided.Implements(t.Elem()) {
+			collected = append(collected, provided)
+		}
+	}
+
+	return collected
+}
+
+// buildCollected assembles a slice of the given type from the values of the collected types.
+func (c *Container) buildCollected(t reflect.Type, collected []reflect.Type, stack *typeStack) (reflect.Value, error) {
+	result := reflect.MakeSlice(t, 0, len(collected))
+
+	for _, member := range collected {
+		value, err := c.resolve(member, stack)
 
 		if err != nil {
-			errorSet.Add(err)
-			break
+			return reflect.Value{}, err
 		}
 
-		dependencies[i] = argValue
+		result = reflect.Append(result, value)
 	}
 
-	if !errorSet.IsEmpty() {
-		return errorSet.Result()
+	return result, nil
+}
+
+// ambiguous returns an AmbiguousDependencyError for the given type listing the candidate types.
+func ambiguous(t reflect.Type, candidates []reflect.Type) error {
+	names := make([]string, len(candidates))
+
+	for i, candidate := range candidates {
+		names[i] = candidate.String()
 	}
 
-	if err := c.hooks.Start(); err != nil {
-		errorSet.Add(err)
+	return errs.AmbiguousDependencyError{TypeName: t.Name(), Candidates: names}
+}
+
+// buildTuple builds one of the types returned by a factory registered with MultiReturn.
+// The factory is called once and its values are kept, so that the other types it returns reuse them.
+func (c *Container) buildTuple(t reflect.Type, provider reflect.Value, outputs []reflect.Type, stack *typeStack, stage *hooks.LifecycleHooks) (reflect.Value, error) {
+	index := slices.Index(outputs, t)
+	key := provider.Type()
+
+	c.mu.RLock()
+	values, exists := c.tuples[key]
+	c.mu.RUnlock()
+
+	if exists {
+		return values[index], nil
 	}
 
-	if !errorSet.IsEmpty() {
-		return errorSet.Result()
+	values, err := c.invokeWithin(t, provider, stack, stage)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if !c.prototype {
+		c.mu.Lock()
+		if existing, exists := c.tuples[key]; exists {
+			values = existing
+		} else {
+			c.tuples[key] = values
+		}
+		c.mu.Unlock()
 	}
 
-	results := reflect.ValueOf(fn).Call(dependencies)
+	return values[index], nil
+}
+
+// buildBinding resolves the concrete type bound to an interface and returns it as a value of the interface.
+// The concrete value is shared with the dependents requesting the concrete type directly.
+func (c *Container) buildBinding(iface, concrete reflect.Type, stack *typeStack) (reflect.Value, error) {
+	value, err := c.resolve(concrete, stack)
 
-	if fnType.NumOut() == 1 && !results[0].IsNil() {
-		errorSet.Add(results[0].Interface().(error))
+	if err != nil {
+		return reflect.Value{}, err
 	}
 
-	if err := c.hooks.Stop(); err != nil {
-		errorSet.Add(err)
+	result := reflect.New(iface).Elem()
+	result.Set(value)
+
+	return result, nil
+}
+
+// decorate applies every decorator registered for the given type, in registration order.
+// Each decorator receives the value produced so far in place of its parameter of that type.
+func (c *Container) decorate(t reflect.Type, value reflect.Value, stack *typeStack, stage *hooks.LifecycleHooks) (reflect.Value, error) {
+	c.mu.RLock()
+	decorators := c.decorators[t]
+	c.mu.RUnlock()
+
+	for _, decorator := range decorators {
+		decorated, err := c.invoke(decorator, stack, map[reflect.Type]reflect.Value{t: value}, stage)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		value = decorated
 	}
 
-	return errorSet.Result()
+	return value, nil
 }
 
-// Merge combines the factories of another container into the current container.
-// If a factory from the other container conflicts with an existing factory in the current container,
-// and they are not identical, a FactoryAlreadyProvidedError is returned.
+// Disable makes the type of the sample resolve as if it was not provided, without removing its registration.
+// Disabled types are also skipped when looking for the concrete type bound to an interface,
+// and keyed factories of a disabled type are not aggregated. Enable restores the type.
+// The sample is a value of the type, or a pointer to it for interfaces.
 //
 // Example:
 //
-//	containerA := New()
-//	containerB := New()
+//	c.Disable((*BetaFeature)(nil))
+func (c *Container) Disable(sample interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.disabled[sampleType(sample)] = true
+}
+
+// Enable restores a type previously disabled with Disable.
 //
-//	containerA.Provide(func() string { return "Hello" })
-//	containerB.Provide(func() int { return 42 })
+// Example:
 //
-//	err := containerA.Merge(containerB)
-//	if err != nil {
-//	    // Handle merge error
-//	}
-func (c *Container) Merge(other *Container) error {
+//	c.Enable((*BetaFeature)(nil))
+func (c *Container) Enable(sample interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for t, factory := range other.providers {
-		if existingFactory, exists := c.providers[t]; exists {
-			if existingFactory.Pointer() != factory.Pointer() {
-				return errs.FactoryAlreadyProvidedError{TypeName: t.Name()}
+	delete(c.disabled, sampleType(sample))
+}
+
+// isDisabled reports whether the given type was disabled with Disable.
+func (c *Container) isDisabled(t reflect.Type) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.disabled[t]
+}
+
+// ResolveType resolves a dependency of the given type, constructing it and its dependencies if needed.
+// Returns the resolved value and any error encountered during resolution.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() int { return 42 })
+//	v, _ := c.ResolveType(reflect.TypeOf(0))
+//	fmt.Println(v.Int()) // Outputs: 42
+func (c *Container) ResolveType(t reflect.Type) (reflect.Value, error) {
+	return c.resolve(t, nil)
+}
+
+// ResolveTypeWithStack resolves a dependency of the given type as part of an in-progress resolution.
+// The stack lists the types currently being constructed, so cycles through them are still detected.
+// This is meant for tooling that builds values from within a factory, such as meta-providers.
+//
+// Example:
+//
+//	stack := []reflect.Type{reflect.TypeOf("")}
+//	_, err := c.ResolveTypeWithStack(reflect.TypeOf(""), stack) // CyclicDependencyError
+func (c *Container) ResolveTypeWithStack(t reflect.Type, stack []reflect.Type) (reflect.Value, error) {
+	return c.resolve(t, newTypeStack(stack))
+}
+
+// invoke calls the given factory after resolving each of its parameters.
+// Returns the first value produced by the factory, or the error it returned.
+func (c *Container) invoke(provider reflect.Value, stack *typeStack, given map[reflect.Type]reflect.Value, stage *hooks.LifecycleHooks) (reflect.Value, error) {
+	values, err := c.invokeAll(provider, stack, given, stage)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return values[0], nil
+}
+
+// invokeWithin calls the factory of the given type like invokeAll, bounded by the timeout set with ConstructTimeout.
+// A factory exceeding it is abandoned: whatever it eventually returns, and the hooks it registers, are discarded.
+func (c *Container) invokeWithin(t reflect.Type, provider reflect.Value, stack *typeStack, stage *hooks.LifecycleHooks) ([]reflect.Value, error) {
+	c.mu.RLock()
+	timeout := c.timeouts[t]
+	c.mu.RUnlock()
+
+	if timeout <= 0 {
+		return c.invokeAll(provider, stack, nil, stage)
+	}
+
+	type outcome struct {
+		values []reflect.Value
+		err    error
+		panic  interface{}
+	}
+
+	staged := new(hooks.LifecycleHooks)
+	isolated := stack.clone()
+	done := make(chan outcome, 1)
+
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				done <- outcome{panic: value}
 			}
-			continue
+		}()
+
+		values, err := c.invokeAll(provider, isolated, nil, staged)
+		done <- outcome{values: values, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		if result.panic != nil {
+			panic(result.panic)
 		}
 
-		c.providers[t] = factory
+		if result.err == nil {
+			stage.Append(staged)
+		}
+
+		return result.values, result.err
+	case <-timer.C:
+		return nil, errs.ConstructTimeoutError{TypeName: t.Name(), Timeout: timeout}
 	}
-	return nil
+}
+
+// invokeAll calls the given factory after resolving each of its parameters.
+// Parameters whose type is present in given receive that value instead of being resolved,
+// Hooks parameters receive the stage collecting the hooks of the current construction,
+// LifecycleContext parameters a context canceled by a stop hook of that stage, and Warnings parameters the log of the container.
+// The stack is forwarded to the parameter resolution to keep cycle detection working.
+// Returns every value produced by the factory except a trailing error, or the error it returned.
+func (c *Container) invokeAll(provider reflect.Value, stack *typeStack, given map[reflect.Type]reflect.Value, stage *hooks.LifecycleHooks) ([]reflect.Value, error) {
+	providerType := provider.Type()
+	var dependencies []reflect.Value
+
+	if numIn := providerType.NumIn(); numIn > 0 {
+		dependencies = make([]reflect.Value, numIn)
+	}
+
+	for i := range dependencies {
+		argType := providerType.In(i)
+
+		if value, ok := given[argType]; ok {
+			dependencies[i] = value
+			continue
+		}
+
+		if argType.Implements(hooksType) {
+			dependencies[i] = c.hooksFor(argType, stage)
+			continue
+		}
+
+		if argType == registryType {
+			dependencies[i] = reflect.ValueOf(c)
+			continue
+		}
+
+		if argType == warningsType {
+			dependencies[i] = reflect.ValueOf(c.warnings)
+			continue
+		}
+
+		if argType == lifecycleContextType {
+			dependencies[i] = lifecycleContext(stage)
+			continue
+		}
+
+		if argType == fieldResolverType {
+			dependencies[i] = reflect.ValueOf(fieldResolver{container: c, stack: stack})
+			continue
+		}
+
+		if top, ok := stack.top(); ok && argType == loggerType && !c.isProvided(loggerType) {
+			dependencies[i] = reflect.ValueOf(c.logger.With("component", top.String()))
+			continue
+		}
+
+		argValue, err := c.resolve(argType, stack)
+
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies[i] = argValue
+	}
+
+	results, err := c.call(provider, dependencies)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if last := len(results) - 1; last > 0 && providerType.Out(last) == errorType {
+		if !results[last].IsNil() {
+			return nil, results[last].Interface().(error)
+		}
+
+		results = results[:last]
+	}
+
+	return results, nil
+}
+
+// isProvided reports whether a factory is registered for the given type.
+func (c *Container) isProvided(t reflect.Type) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, exists := c.providers[t]
+	return exists
+}
+
+// call invokes the function with the given arguments.
+// When the container recovers panics, a panic raised by the function is returned as an errs.PanicError
+// naming the factory type or the function, otherwise it propagates to the caller.
+func (c *Container) call(fn reflect.Value, args []reflect.Value) (results []reflect.Value, err error) {
+	if c.recovering {
+		defer func() {
+			if value := recover(); value != nil {
+				err = errs.PanicError{Source: describe(fn), Value: value}
+			}
+		}()
+	}
+
+	return fn.Call(args), nil
+}
+
+// describe names a function for error messages.
+// Factories are named after the type they build, other functions after their symbol.
+func describe(fn reflect.Value) string {
+	if fnType := fn.Type(); fnType.NumOut() > 0 && fnType.Out(0) != errorType {
+		return "factory for type " + fnType.Out(0).String()
+	}
+
+	return "function " + runtime.FuncForPC(fn.Pointer()).Name()
+}
+
+// keyedProviders returns the keyed factories able to build the given map type.
+// Only map[string]T types are eligible, where T is the return type of the keyed factories.
+func (c *Container) keyedProviders(t reflect.Type) (map[string]reflect.Value, bool) {
+	if t.Kind() != reflect.Map || t.Key() != reflect.TypeOf("") {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keyed, exists := c.keyed[t.Elem()]
+	return keyed, exists && len(keyed) > 0 && !c.disabled[t.Elem()]
+}
+
+// buildKeyed assembles a map of the given type from its keyed factories.
+func (c *Container) buildKeyed(t reflect.Type, keyed map[string]reflect.Value, stack *typeStack, stage *hooks.LifecycleHooks) (reflect.Value, error) {
+	result := reflect.MakeMapWithSize(t, len(keyed))
+
+	for key, provider := range keyed {
+		value, err := c.invoke(provider, stack, nil, stage)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetMapIndex(reflect.ValueOf(key), value)
+	}
+
+	return result, nil
+}
+
+// validateCleanup ensures that a cleanup takes a single value of one of the given types and returns at most an error.
+func validateCleanup(cleanupType reflect.Type, outputs []reflect.Type) error {
+	if cleanupType == nil || cleanupType.Kind() != reflect.Func {
+		return errs.NotAFunctionError{}
+	}
+
+	if cleanupType.NumIn() != 1 || !slices.Contains(outputs, cleanupType.In(0)) {
+		return errs.InvalidCleanupError{TypeName: outputs[0].Name()}
+	}
+
+	if cleanupType.NumOut() > 1 || (cleanupType.NumOut() == 1 && cleanupType.Out(0) != errorType) {
+		return errs.InvalidCleanupError{TypeName: cleanupType.In(0).Name()}
+	}
+
+	return nil
+}
+
+// factoryOutputs returns the types produced by a factory, leaving out a trailing error.
+func factoryOutputs(factoryType reflect.Type) []reflect.Type {
+	outputs := make([]reflect.Type, factoryType.NumOut())
+
+	for i := range outputs {
+		outputs[i] = factoryType.Out(i)
+	}
+
+	if last := len(outputs) - 1; last > 0 && outputs[last] == errorType {
+		outputs = outputs[:last]
+	}
+
+	return outputs
+}
+
+// validateMultiFactory ensures that the given type is a function returning distinct non-error values,
+// optionally followed by an error, as accepted by the MultiReturn option.
+func validateMultiFactory(factoryType reflect.Type) error {
+	if factoryType == nil || factoryType.Kind() != reflect.Func {
+		return errs.NotAFunctionError{}
+	}
+
+	if factoryType.NumOut() < 1 {
+		return errs.InvalidFactoryReturnError{NumReturns: 0}
+	}
+
+	outputs := factoryOutputs(factoryType)
+
+	for i, output := range outputs {
+		if output == errorType || slices.Contains(outputs[:i], output) {
+			return errs.UnexpectedReturnTypeError{TypeName: output.Name()}
+		}
+	}
+
+	return nil
+}
+
+// validateFactory ensures that the given type is a function with a valid return signature.
+// Factories must return a single value, optionally followed by an error.
+func validateFactory(factoryType reflect.Type) error {
+	if factoryType == nil || factoryType.Kind() != reflect.Func {
+		return errs.NotAFunctionError{}
+	}
+
+	if numOut := factoryType.NumOut(); numOut < 1 || numOut > 2 {
+		return errs.InvalidFactoryReturnError{NumReturns: numOut}
+	}
+
+	if factoryType.NumOut() == 2 {
+		if !factoryType.Out(1).Implements(errorType) {
+			return errs.UnexpectedReturnTypeError{TypeName: factoryType.Out(1).Name()}
+		}
+	}
+
+	return nil
+}
+
+// Provide registers a factory function for dependency resolution.
+// It ensures that the factory is a function, has a valid return type, and checks for duplicate factories.
+// ProvideOption values can be mixed with the factories and apply to every factory of the call.
+// A factory returning errs.ErrSkip leaves its type to the alternatives, such as bindings and converters.
+// Returns an error if any of these conditions are not met.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() int { return 42 })
+//	c.Provide(NewFileStore, zeus.As(new(Store)))
+func (c *Container) Provide(factories ...interface{}) error {
+	config, factories := collectProvideOptions(factories)
+	targets := slices.Clone(config.as)
+
+	if config.group == "" {
+		targets = append(targets, returnTypes(factories)...)
+	}
+
+	stale, err := c.stale(targets)
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	validate := validateFactory
+
+	if config.multi {
+		validate = validateMultiFactory
+	}
+
+	for _, factory := range factories {
+		factoryType := reflect.TypeOf(factory)
+
+		if err := validate(factoryType); err != nil {
+			return err
+		}
+
+		if config.group != "" {
+			c.groups[config.group] = append(c.groups[config.group], &groupMember{factory: reflect.ValueOf(factory), priority: config.priority})
+			continue
+		}
+
+		serviceType := factoryType.Out(0)
+		outputs := factoryOutputs(factoryType)
+
+		for _, output := range outputs {
+			if _, exists := c.providers[output]; exists {
+				return errs.FactoryAlreadyProvidedError{TypeName: output.Name()}
+			}
+		}
+
+		for _, iface := range config.as {
+			if iface.Kind() != reflect.Interface {
+				return errs.NotAnInterfaceError{TypeName: iface.Name()}
+			}
+
+			if !serviceType.Implements(iface) {
+				return errs.InterfaceNotImplementedError{TypeName: serviceType.Name(), InterfaceName: iface.Name()}
+			}
+		}
+
+		for _, cleanup := range config.cleanups {
+			if err := validateCleanup(reflect.TypeOf(cleanup), outputs); err != nil {
+				return err
+			}
+		}
+
+		for _, output := range outputs {
+			c.providers[output] = reflect.ValueOf(factory)
+			c.registered = append(c.registered, output)
+
+			if c.strict && output.Kind() == reflect.Interface {
+				c.logger.Warn("factory returns an interface, so the concrete type it builds cannot be requested", "type", output.String())
+			}
+
+			if config.timeout > 0 {
+				c.timeouts[output] = config.timeout
+			}
+
+			if config.flag != "" {
+				c.flags[output] = config.flag
+			}
+
+			if len(config.optional) > 0 {
+				c.optionals[output] = config.optional
+			}
+		}
+
+		for _, iface := range config.as {
+			c.bindings[iface] = append(c.bindings[iface], serviceType)
+		}
+
+		for _, cleanup := range config.cleanups {
+			cleanupValue := reflect.ValueOf(cleanup)
+			valueType := cleanupValue.Type().In(0)
+			c.cleanups[valueType] = append(c.cleanups[valueType], cleanupValue)
+		}
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// ProvideAs registers a factory and binds its return type to the given interfaces.
+// It is a shorthand for Provide(factory, As(interfaces...)).
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.ProvideAs(NewFileStore, new(Store))
+func (c *Container) ProvideAs(factory interface{}, interfaces ...interface{}) error {
+	return c.Provide(factory, As(interfaces...))
+}
+
+// ProvideConverter registers functions that derive a type from another one.
+// A converter takes a single parameter and returns the derived type, optionally followed by an error.
+// When the derived type has no factory, it is resolved by resolving the parameter and converting it,
+// which may in turn go through another converter. Several converters to the same type make it ambiguous.
+// Returns an error if a converter is not a valid factory or does not take exactly one parameter.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(LoadConfig)
+//	c.ProvideConverter(func(cfg Config) DBConfig { return cfg.Database })
+func (c *Container) ProvideConverter(converters ...interface{}) error {
+	stale, err := c.stale(returnTypes(converters))
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, converter := range converters {
+		converterType := reflect.TypeOf(converter)
+
+		if err := validateFactory(converterType); err != nil {
+			return err
+		}
+
+		targetType := converterType.Out(0)
+
+		if converterType.NumIn() != 1 || converterType.In(0) == targetType {
+			return errs.InvalidConverterError{TypeName: targetType.Name()}
+		}
+
+		c.converters[targetType] = append(c.converters[targetType], reflect.ValueOf(converter))
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// ProvideKeyed registers a factory under the given key.
+// Keyed factories sharing a return type T are aggregated into a map[string]T,
+// which is injected wherever that map type is requested and no factory for the map itself exists.
+// Returns an error if the factory is invalid or if the key is already taken for that type.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.ProvideKeyed("primary", func() string { return "db-1:5432" })
+//	c.ProvideKeyed("replica", func() string { return "db-2:5432" })
+//	c.Run(func(endpoints map[string]string) {
+//	    fmt.Println(endpoints["replica"]) // Outputs: db-2:5432
+//	})
+func (c *Container) ProvideKeyed(key string, factory interface{}) error {
+	targets := returnTypes([]interface{}{factory})
+
+	for i, t := range targets {
+		targets[i] = reflect.MapOf(reflect.TypeOf(""), t)
+	}
+
+	stale, err := c.stale(targets)
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	factoryType := reflect.TypeOf(factory)
+
+	if err := validateFactory(factoryType); err != nil {
+		return err
+	}
+
+	serviceType := factoryType.Out(0)
+
+	if _, exists := c.keyed[serviceType][key]; exists {
+		return errs.KeyAlreadyProvidedError{Key: key, TypeName: serviceType.Name()}
+	}
+
+	if c.keyed[serviceType] == nil {
+		c.keyed[serviceType] = make(map[string]reflect.Value)
+	}
+
+	c.keyed[serviceType][key] = reflect.ValueOf(factory)
+	c.invalidate(stale)
+
+	return nil
+}
+
+// Decorate registers functions that wrap the value built for a type.
+// A decorator returns the decorated type and takes it as a parameter, receiving the value built so far as the inner one.
+// Its other parameters are resolved from the container like any factory dependency.
+// Decorators of the same type are applied by decreasing priority, set with the Priority option,
+// then in registration order, each wrapping the previous result. The default priority is zero.
+// Returns an error if a decorator is not a valid factory or does not take its own return type.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() Handler { return baseHandler })
+//	c.Decorate(func(inner Handler, log *slog.Logger) Handler {
+//	    return loggingHandler{inner, log}
+//	})
+//	c.Decorate(func(inner Handler) Handler { return recoveryHandler{inner} }, zeus.Priority(10))
+func (c *Container) Decorate(decorators ...interface{}) error {
+	config, decorators := collectProvideOptions(decorators)
+	stale, err := c.stale(returnTypes(decorators))
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, decorator := range decorators {
+		decoratorType := reflect.TypeOf(decorator)
+
+		if err := validateFactory(decoratorType); err != nil {
+			return err
+		}
+
+		serviceType := decoratorType.Out(0)
+		isDecorator := false
+
+		for i := 0; i < decoratorType.NumIn(); i++ {
+			isDecorator = isDecorator || decoratorType.In(i) == serviceType
+		}
+
+		if !isDecorator {
+			return errs.InvalidDecoratorError{TypeName: serviceType.Name()}
+		}
+
+		c.insertDecorator(serviceType, reflect.ValueOf(decorator), config.priority)
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// insertDecorator adds a decorator of the given type after those with a higher or equal priority.
+// The caller must hold the lock for writing.
+func (c *Container) insertDecorator(t reflect.Type, decorator reflect.Value, priority int) {
+	priorities := c.decoratorPriorities[t]
+	position := len(priorities)
+
+	for position > 0 && priorities[position-1] < priority {
+		position--
+	}
+
+	// Clipping makes the insertion copy the slices, which overlays may share.
+	c.decorators[t] = slices.Insert(slices.Clip(c.decorators[t]), position, decorator)
+	c.decoratorPriorities[t] = slices.Insert(slices.Clip(priorities), position, priority)
+}
+
+// RemoveDecorators drops every decorator registered for the type of the sample, for instance to disable tracing at runtime.
+// The cached value of the type, and of the types depending on it, are dropped along with their hooks,
+// whatever the policy set with OnLateProvide, so that the next resolution builds them without the decorators.
+// The sample is a value of the type, or a pointer to it for interfaces.
+// Returns an errs.FrozenError if the container has been frozen.
+//
+// Example:
+//
+//	c.RemoveDecorators((*Handler)(nil))
+func (c *Container) RemoveDecorators(sample interface{}) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	t := sampleType(sample)
+	affected := c.dependents([]reflect.Type{t})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.decorators, t)
+	delete(c.decoratorPriorities, t)
+
+	stale := []reflect.Type{}
+
+	for t := range affected {
+		if _, exists := c.cached(t); exists {
+			stale = append(stale, t)
+		}
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// ProvideDecorator registers a self-referential factory, one that takes the type it produces.
+// It is the provider form of Decorate: the inner value is the one built by the regular factory
+// and any decorator registered before, so the self-reference is not reported as a cycle.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() Handler { return baseHandler })
+//	c.ProvideDecorator(func(inner Handler) Handler { return authHandler{inner} })
+func (c *Container) ProvideDecorator(decorators ...interface{}) error {
+	return c.Decorate(decorators...)
+}
+
+// Warm eagerly builds the types of the given samples concurrently.
+// Each sample is a value of the type to build, or a pointer to it for interfaces, e.g. (*io.Reader)(nil).
+// The types share the singleton cache, so a dependency common to several of them is constructed once.
+// At most as many types as set with MaxConcurrency are built at the same time.
+// Returns the aggregated errors of every type that failed to build.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(NewDatabase, NewCache)
+//	err := c.Warm((*Database)(nil), (*Cache)(nil))
+func (c *Container) Warm(samples ...interface{}) error {
+	return c.WarmContext(context.Background(), samples...)
+}
+
+// WarmContext builds the types of the given samples like Warm, until the context is done.
+// Once it is, no other type starts building and the context error is returned right away:
+// the constructions already in progress are abandoned to finish in the background, and still cache their values.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	err := c.WarmContext(ctx, (*Database)(nil), (*Cache)(nil))
+func (c *Container) WarmContext(ctx context.Context, samples ...interface{}) error {
+	errorSet := &errs.ErrorSet{}
+	types := make([]reflect.Type, len(samples))
+
+	for i, sample := range samples {
+		types[i] = sampleType(sample)
+
+		if err := c.detectCycle(types[i]); err != nil {
+			return err
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	limit := max(len(types), 1)
+
+	if c.concurrency > 0 {
+		limit = min(limit, c.concurrency)
+	}
+
+	slots := make(chan struct{}, limit)
+	launched := 0
+
+	for _, t := range types {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		launched++
+		wg.Add(1)
+
+		go func(t reflect.Type) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if _, err := c.resolve(t, nil); err != nil {
+				errorSet.Add(err)
+			}
+		}(t)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if launched < len(types) {
+		return ctx.Err()
+	}
+
+	return errorSet.Result()
+}
+
+// Run executes the provided function by resolving and injecting its dependencies.
+// It ensures that the function has a valid signature and that all dependencies can be resolved.
+// Returns an error if the function signature is invalid or if dependencies cannot be resolved.
+// Every parameter is resolved before returning, so all resolution errors are reported together.
+// Start and stop hooks run around the function, unless the container was already started with Build,
+// in which case Close is responsible for stopping it.
+// RunOption values customize a single call, such as OverrideFor.
+//
+// Errors are reported by phase, in a fixed order: resolution errors by parameter position, then the start hook error,
+// then the error of the function, then the error of each failing stop hook. Resolution and start errors end the call,
+// so they are never mixed with the errors of the later phases.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() int { return 42 })
+//	c.Run(func(i int) {
+//	    fmt.Println(i) // Outputs: 42
+//	})
+func (c *Container) Run(fn interface{}, options ...RunOption) error {
+	return c.RunContext(context.Background(), fn, options...)
+}
+
+// RunContext executes the provided function like Run, threading the context to the stop hooks.
+// Hooks registered with OnStopContext receive the context, so they can honor its deadline.
+// If the context is already done when stopping, the hooks receive a fresh context bounded by
+// the grace period of the container instead, so they still get a chance to release resources.
+//
+// Example:
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//	c.RunContext(ctx, serve)
+func (c *Container) RunContext(ctx context.Context, fn interface{}, options ...RunOption) error {
+	config := new(runConfig)
+
+	for _, option := range options {
+		option(config)
+	}
+
+	_, err := c.run(ctx, fn, config)
+
+	return err
+}
+
+// RunCapture executes the provided function like Run and returns the values injected into it, in parameter order,
+// so that tests can make assertions on them without resolving them again.
+// The values are returned even if the function or the hooks fail, since they were built, but not if a parameter
+// could not be resolved.
+//
+// Example:
+//
+//	args, err := c.RunCapture(func(db *Database, cache *Cache) error { return nil })
+//	db := args[0].(*Database)
+func (c *Container) RunCapture(fn interface{}) ([]interface{}, error) {
+	dependencies, err := c.run(context.Background(), fn, new(runConfig))
+
+	if dependencies == nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(dependencies))
+
+	for i, dependency := range dependencies {
+		values[i] = dependency.Interface()
+	}
+
+	return values, err
+}
+
+// RunDry resolves the dependencies of the provided function and runs the start and stop hooks like Run,
+// but never calls the function, so tests can check the construction and lifecycle wiring without the real workload.
+// Returns the resolution and hook errors, in the same order as Run.
+//
+// Example:
+//
+//	err := c.RunDry(serve)
+func (c *Container) RunDry(fn interface{}) error {
+	_, err := c.run(context.Background(), fn, &runConfig{dry: true})
+
+	return err
+}
+
+// run executes the function as described in RunContext, and returns the values injected into it.
+// They are returned once every parameter is resolved, even if the hooks or the function fail afterwards.
+// The config may split the run for a Runner: started is called once the start hooks succeed, and with a release channel
+// the function is called in its own goroutine while the stop hooks wait for the channel to be closed.
+func (c *Container) run(ctx context.Context, fn interface{}, config *runConfig) ([]reflect.Value, error) {
+	if len(config.overrides) > 0 {
+		overlay, err := c.overlay(config.overrides)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The run counts as one of the container too, since fn may resolve from it while the overlay is running.
+		c.running.Add(1)
+		defer c.running.Add(-1)
+
+		return overlay.run(ctx, fn, &runConfig{strategy: config.strategy, tagParams: config.tagParams, dry: config.dry, started: config.started, release: config.release})
+	}
+
+	errorSet := &errs.ErrorSet{Strategy: config.strategy}
+
+	fnType := reflect.TypeOf(fn)
+
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil, errs.NotAFunctionError{}
+	}
+
+	if numOut := fnType.NumOut(); numOut > 1 {
+		return nil, errs.InvalidFactoryReturnError{NumReturns: numOut}
+	}
+
+	if fnType.NumOut() == 1 && fnType.Out(0).Name() != "error" {
+		return nil, errs.UnexpectedReturnTypeError{TypeName: fnType.Out(0).Name()}
+	}
+
+	if numIn := fnType.NumIn(); c.maxParams > 0 && numIn > c.maxParams {
+		return nil, errs.TooManyParametersError{Count: numIn, Max: c.maxParams}
+	}
+
+	if c.requireError && fnType.NumOut() == 0 {
+		return nil, errs.MissingErrorReturnError{}
+	}
+
+	begun := time.Now()
+	stopped := false
+
+	c.mu.Lock()
+	c.closed = false
+	c.running.Add(1)
+	c.mu.Unlock()
+
+	// The last run to stop its hooks closes the container, like Close, so that goroutines outliving it cannot resolve.
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.running.Add(-1) == 0 && stopped {
+			c.closed = true
+		}
+	}()
+
+	var timings Timings
+	watch := c.stopwatch()
+	defer watch.record(&timings)
+
+	mark := c.mark()
+	dependencies := make([]reflect.Value, fnType.NumIn())
+	dependencyTypes := make([]reflect.Type, fnType.NumIn())
+	stack := newTypeStack(nil)
+	watch.begin()
+
+	for i := range dependencies {
+		argType := fnType.In(i)
+		dependencyTypes[i] = argType
+		argValue, err := c.resolve(argType, stack)
+
+		if err != nil && config.tagParams {
+			errorSet.Add(errs.ParameterError{Index: i, TypeName: argType.String(), Err: err})
+			continue
+		}
+
+		if err != nil {
+			errorSet.Add(err)
+			continue
+		}
+
+		dependencies[i] = argValue
+	}
+
+	if err := c.invokeLifecycles(); err != nil {
+		addFlattened(errorSet, err)
+	}
+
+	watch.end(&timings.Resolve)
+
+	if !errorSet.IsEmpty() {
+		return nil, errorSet.Result()
+	}
+
+	managed := c.isStarted()
+	records := c.runRecords(c.reachable(dependencyTypes), mark)
+
+	if c.prototype {
+		defer c.forget(records)
+	}
+
+	if !managed {
+		watch.begin()
+		err := c.start(c.collectHooks(records))
+		watch.end(&timings.Start)
+
+		if err != nil {
+			errorSet.Add(err)
+			return dependencies, errorSet.Result()
+		}
+	}
+
+	if c.logPlan {
+		c.logger.Info("wiring plan", "plan", typeNames(c.plan(factoryDependencies(fnType))), "hooks", typeNames(c.hookTypes(records)))
+	}
+
+	if !managed {
+		recordStartup(c.startup, begun)
+	}
+
+	if config.started != nil {
+		config.started()
+	}
+
+	// invoke calls the function and returns its error, timing it with the given stopwatch.
+	invoke := func(watch *stopwatch) error {
+		watch.begin()
+		results, err := c.call(reflect.ValueOf(fn), dependencies)
+		watch.end(&timings.Function)
+
+		if err == nil && fnType.NumOut() == 1 && !results[0].IsNil() {
+			err = results[0].Interface().(error)
+		}
+
+		return err
+	}
+
+	var returned chan error
+
+	switch {
+	case config.dry:
+	case config.release != nil:
+		returned = make(chan error, 1)
+		go func() { returned <- invoke(c.stopwatch()) }()
+	default:
+		if err := invoke(watch); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	var stopErr error
+
+	if config.release != nil {
+		<-config.release
+	}
+
+	if !managed {
+		watch.begin()
+		c.live.Add(-1)
+		stopErr = c.stop(ctx, c.stopHooks(records))
+		watch.end(&timings.Stop)
+	}
+
+	if returned != nil {
+		if err := <-returned; err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	if stopErr != nil {
+		addFlattened(errorSet, stopErr)
+	}
+
+	if !managed {
+		c.settle(records)
+		stopped = true
+	}
+
+	return dependencies, errorSet.Result()
+}
+
+// addFlattened adds the given error to the set, or each of its errors if it is an ErrorSet itself,
+// so that the errors of a phase keep their order among the others.
+func addFlattened(errorSet *errs.ErrorSet, err error) {
+	nested, ok := err.(*errs.ErrorSet)
+
+	if !ok {
+		errorSet.Add(err)
+		return
+	}
+
+	for _, err := range nested.Errors() {
+		errorSet.Add(err)
+	}
+}
+
+// Merge combines the factories of another container into the current container.
+// If a factory from the other container conflicts with an existing factory in the current container,
+// and they are not identical, a FactoryAlreadyProvidedError is returned.
+// Group members are never conflicts: those of the other container join the groups after the current members.
+// What the other container registered around its factories, such as decorators, bindings, converters, aliases,
+// keyed factories, cleanups, construction timeouts and feature flags, is merged along with them.
+// Values the other container already built are not merged, so the current container builds its own: see MergeWith.
+//
+// Example:
+//
+//	containerA := New()
+//	containerB := New()
+//
+//	containerA.Provide(func() string { return "Hello" })
+//	containerB.Provide(func() int { return 42 })
+//
+//	err := containerA.Merge(containerB)
+//	if err != nil {
+//	    // Handle merge error
+//	}
+func (c *Container) Merge(other *Container) error {
+	return c.MergeWith(other, InstancesDrop)
+}
+
+// InstancePolicy decides what MergeWith does with the values the merged container already built.
+type InstancePolicy int
+
+const (
+	// InstancesDrop leaves the built values behind, so the merged factories build them again, with a fresh lifecycle.
+	// It is the behavior of Merge.
+	InstancesDrop InstancePolicy = iota
+	// InstancesReuse copies the built values along with their factories, so they are not built again.
+	// Their hooks stay with the container that built them.
+	InstancesReuse
+	// InstancesError rejects the merge with an errs.CachedInstanceError if any merged type was already built.
+	InstancesError
+)
+
+// MergeWith combines the factories of another container into the current container like Merge,
+// applying the given policy to the values the other container already built for the merged types.
+// With InstancesError, the merge is rejected before anything is merged.
+//
+// Example:
+//
+//	err := app.MergeWith(module, zeus.InstancesReuse)
+func (c *Container) MergeWith(other *Container, policy InstancePolicy) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	if other == c {
+		return nil
+	}
+
+	// Reused values may evict others with CacheSize, whose stop hooks run once the locks are released.
+	evicted := new(hooks.LifecycleHooks)
+
+	defer func() {
+		if err := evicted.Stop(); err != nil {
+			c.logger.Warn("stop hooks of an evicted instance failed", "error", err)
+		}
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	if policy == InstancesError {
+		for _, t := range other.registered {
+			if _, cached := other.cached(t); cached {
+				return errs.CachedInstanceError{TypeName: t.String()}
+			}
+		}
+	}
+
+	merged := []reflect.Type{}
+
+	for _, t := range other.registered {
+		factory := other.providers[t]
+
+		if existingFactory, exists := c.providers[t]; exists {
+			if existingFactory.Pointer() != factory.Pointer() {
+				return errs.FactoryAlreadyProvidedError{TypeName: t.Name()}
+			}
+			continue
+		}
+
+		c.providers[t] = factory
+		c.registered = append(c.registered, t)
+		merged = append(merged, t)
+
+		if instance, cached := other.cached(t); cached && policy == InstancesReuse {
+			if stage := c.cache(t, instance); stage != nil {
+				evicted.Append(stage)
+			}
+		}
+	}
+
+	c.mergeGroups(other)
+
+	errorSet := &errs.ErrorSet{}
+
+	for _, err := range c.mergeRegistrations(other, merged) {
+		errorSet.Add(err)
+	}
+
+	return errorSet.Result()
+}
+
+// MergeNamespaced combines the factories of another container into the current container under the given prefix,
+// so that they never conflict with the factories already registered.
+// Each factory of the other container is registered as a keyed factory of its type, keyed by the prefix,
+// and each keyed factory by the prefix followed by its key, to be selected later from the map[string]T of its type.
+// A factory returning several types is registered once per type. The merged factories resolve their dependencies
+// from the current container. Returns the errors of ProvideKeyed, aggregated in an ErrorSet when there are several of them.
+//
+// Example:
+//
+//	containerA.MergeNamespaced(containerB, "b.")
+//	containerA.Run(func(clients map[string]*http.Client) {
+//	    client := clients["b."] // The *http.Client provided by containerB
+//	})
+func (c *Container) MergeNamespaced(other *Container, prefix string) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	type namespaced struct {
+		key     string
+		factory reflect.Value
+	}
+
+	other.mu.RLock()
+	merged := make([]namespaced, 0, len(other.registered))
+
+	for _, t := range other.registered {
+		merged = append(merged, namespaced{key: prefix, factory: project(other.providers[t], t)})
+	}
+
+	keyedTypes := make([]reflect.Type, 0, len(other.keyed))
+
+	for t := range other.keyed {
+		keyedTypes = append(keyedTypes, t)
+	}
+
+	for _, t := range sortTypes(keyedTypes) {
+		keys := make([]string, 0, len(other.keyed[t]))
+
+		for key := range other.keyed[t] {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			merged = append(merged, namespaced{key: prefix + key, factory: other.keyed[t][key]})
+		}
+	}
+
+	other.mu.RUnlock()
+
+	errorSet := &errs.ErrorSet{}
+
+	for _, entry := range merged {
+		if err := c.ProvideKeyed(entry.key, entry.factory.Interface()); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	return errorSet.Result()
+}
+
+// project returns a factory building only the given type out of the given factory,
+// which may return several types. The factory itself is returned when it builds only that type.
+func project(factory reflect.Value, t reflect.Type) reflect.Value {
+	factoryType := factory.Type()
+
+	if validateFactory(factoryType) == nil && factoryType.Out(0) == t {
+		return factory
+	}
+
+	index := 0
+
+	for i := 0; i < factoryType.NumOut(); i++ {
+		if factoryType.Out(i) == t {
+			index = i
+		}
+	}
+
+	ins := make([]reflect.Type, factoryType.NumIn())
+
+	for i := range ins {
+		ins[i] = factoryType.In(i)
+	}
+
+	outs := []reflect.Type{t}
+	last := factoryType.NumOut() - 1
+	failable := factoryType.Out(last) == errorType
+
+	if failable {
+		outs = append(outs, errorType)
+	}
+
+	projectedType := reflect.FuncOf(ins, outs, factoryType.IsVariadic())
+
+	return reflect.MakeFunc(projectedType, func(args []reflect.Value) []reflect.Value {
+		var results []reflect.Value
+
+		if factoryType.IsVariadic() {
+			results = factory.CallSlice(args)
+		} else {
+			results = factory.Call(args)
+		}
+
+		if failable {
+			return []reflect.Value{results[index], results[last]}
+		}
+
+		return []reflect.Value{results[index]}
+	})
+}
+
+// sampleType returns the type represented by a sample value.
+// Pointers to interfaces stand for the interface itself, since interface values cannot be passed directly.
+func sampleType(sample interface{}) reflect.Type {
+	t := reflect.TypeOf(sample)
+
+	if t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Interface {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// MergeAll combines the factories of another container into the current container, like Merge,
+// but keeps going after a conflict so that every conflicting type is reported at once.
+// Factories that do not conflict are merged, and the conflicts are returned as FactoryAlreadyProvidedErrors
+// sorted by type name, aggregated in an ErrorSet when there are several of them.
+//
+// Example:
+//
+//	err := containerA.MergeAll(containerB)
+//	if es, ok := err.(*zeus.ErrorSet); ok {
+//	    for _, e := range es.Errors() {
+//	        fmt.Println(e) // Each conflicting type
+//	    }
+//	}
+func (c *Container) MergeAll(other *Container) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	if other == c {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	conflicts := []reflect.Type{}
+	merged := []reflect.Type{}
+
+	for _, t := range other.registered {
+		factory := other.providers[t]
+
+		if existingFactory, exists := c.providers[t]; exists {
+			if existingFactory.Pointer() != factory.Pointer() {
+				conflicts = append(conflicts, t)
+			}
+			continue
+		}
+
+		c.providers[t] = factory
+		c.registered = append(c.registered, t)
+		merged = append(merged, t)
+	}
+
+	c.mergeGroups(other)
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].String() < conflicts[j].String()
+	})
+
+	errorSet := &errs.ErrorSet{}
+
+	for _, t := range conflicts {
+		errorSet.Add(errs.FactoryAlreadyProvidedError{TypeName: t.Name()})
+	}
+
+	for _, err := range c.mergeRegistrations(other, merged) {
+		errorSet.Add(err)
+	}
+
+	return errorSet.Result()
+}
+
+// mergeRegistrations copies what the other container registered around its factories: the per-type settings of the
+// merged types, such as cleanups, construction timeouts, feature flags and supplied marks, then its keyed factories,
+// bindings, aliases, converters, decorators and ordering constraints, skipping those already registered.
+// Keys and aliases the current container registered differently are kept, and reported as conflicts.
+// The caller must hold the lock of the current container for writing and the lock of the other one for reading.
+func (c *Container) mergeRegistrations(other *Container, merged []reflect.Type) []error {
+	conflicts := []error{}
+
+	for _, t := range merged {
+		if cleanups, exists := other.cleanups[t]; exists {
+			c.cleanups[t] = slices.Clone(cleanups)
+		}
+
+		if optional, exists := other.optionals[t]; exists {
+			c.optionals[t] = optional
+		}
+
+		if timeout, exists := other.timeouts[t]; exists {
+			c.timeouts[t] = timeout
+		}
+
+		if flag, exists := other.flags[t]; exists {
+			c.flags[t] = flag
+		}
+
+		if other.supplied[t] {
+			c.supplied[t] = true
+		}
+	}
+
+	for _, t := range sortTypes(mapKeys(other.keyed)) {
+		keys := make([]string, 0, len(other.keyed[t]))
+
+		for key := range other.keyed[t] {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			factory := other.keyed[t][key]
+
+			if existing, exists := c.keyed[t][key]; exists {
+				if existing.Pointer() != factory.Pointer() {
+					conflicts = append(conflicts, errs.KeyAlreadyProvidedError{Key: key, TypeName: t.Name()})
+				}
+				continue
+			}
+
+			if c.keyed[t] == nil {
+				c.keyed[t] = make(map[string]reflect.Value)
+			}
+
+			c.keyed[t][key] = factory
+		}
+	}
+
+	for _, t := range sortTypes(mapKeys(other.aliases)) {
+		if existing, exists := c.aliases[t]; exists {
+			if existing != other.aliases[t] {
+				conflicts = append(conflicts, errs.FactoryAlreadyProvidedError{TypeName: t.Name()})
+			}
+			continue
+		}
+
+		c.aliases[t] = other.aliases[t]
+	}
+
+	for t, concretes := range other.bindings {
+		for _, concrete := range concretes {
+			if !slices.Contains(c.bindings[t], concrete) {
+				c.bindings[t] = append(c.bindings[t], concrete)
+			}
+		}
+	}
+
+	for t, successors := range other.ordering {
+		for _, successor := range successors {
+			if !slices.Contains(c.ordering[t], successor) {
+				c.ordering[t] = append(c.ordering[t], successor)
+			}
+		}
+	}
+
+	for t, converters := range other.converters {
+		for _, converter := range converters {
+			if !containsFunc(c.converters[t], converter) {
+				c.converters[t] = append(c.converters[t], converter)
+			}
+		}
+	}
+
+	for t, decorators := range other.decorators {
+		for i, decorator := range decorators {
+			if !containsFunc(c.decorators[t], decorator) {
+				c.insertDecorator(t, decorator, other.decoratorPriorities[t][i])
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// containsFunc reports whether the given functions include one with the same code pointer as fn.
+func containsFunc(fns []reflect.Value, fn reflect.Value) bool {
+	return slices.ContainsFunc(fns, func(candidate reflect.Value) bool {
+		return candidate.Pointer() == fn.Pointer()
+	})
+}
+
+// mapKeys returns the types keying the given map, in no particular order.
+func mapKeys[V any](m map[reflect.Type]V) []reflect.Type {
+	keys := make([]reflect.Type, 0, len(m))
+
+	for t := range m {
+		keys = append(keys, t)
+	}
+
+	return keys
 }
@@ -0,0 +1,90 @@
+package zeus
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func BenchmarkResolve(b *testing.B) {
+	type A struct{}
+	type B struct{}
+	type C struct{}
+
+	benchmarks := []struct {
+		name    string
+		factory interface{}
+	}{
+		{"0 args", func() string { return "" }},
+		{"1 arg", func(a *A) string { return "" }},
+		{"2 args", func(a *A, b *B) string { return "" }},
+		{"3 args", func(a *A, b *B, c *C) string { return "" }},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			c := New(Prototype())
+			c.Provide(func() *A { return nil }, func() *B { return nil }, func() *C { return nil })
+			c.Provide(bm.factory)
+
+			t := reflect.TypeOf("")
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := c.resolve(t, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	b.Run("singleton", func(b *testing.B) {
+		c := New()
+		c.Provide(func() int { return 42 })
+
+		t := reflect.TypeOf(0)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := c.resolve(t, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkResolveChain(b *testing.B) {
+	for _, depth := range []int{8, 64, 512, 4096} {
+		b.Run(fmt.Sprintf("depth %d", depth), func(b *testing.B) {
+			c := New(Prototype())
+			t := provideChain(c, depth)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := c.resolve(t, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// provideChain registers factories building a chain of distinct pointer types, each depending on the previous one,
+// and returns the type at the end of the chain.
+func provideChain(c *Container, depth int) reflect.Type {
+	previous := reflect.TypeOf(0)
+	c.Provide(func() int { return 0 })
+
+	for i := 1; i < depth; i++ {
+		t := reflect.PointerTo(previous)
+		c.Provide(reflect.MakeFunc(reflect.FuncOf([]reflect.Type{previous}, []reflect.Type{t}, false), func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.Zero(t)}
+		}).Interface())
+		previous = t
+	}
+
+	return previous
+}
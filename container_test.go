@@ -1,11 +1,18 @@
 package zeus
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/otoru/zeus/errs"
 	"gotest.tools/v3/assert"
@@ -20,7 +27,7 @@ func TestContainer(t *testing.T) {
 		t.Run("Cyclic dependency", func(t *testing.T) {
 			c := New()
 			c.Provide(func(s string) string { return s })
-			_, got := c.resolve(reflect.TypeOf(""), []reflect.Type{reflect.TypeOf("")})
+			_, got := c.resolve(reflect.TypeOf(""), newTypeStack([]reflect.Type{reflect.TypeOf("")}))
 			expected := errs.CyclicDependencyError{TypeName: "string"}
 
 			assert.ErrorIs(t, got, expected)
@@ -104,6 +111,27 @@ func TestContainer(t *testing.T) {
 			assert.Equal(t, a.C, b.C)
 		})
 
+		t.Run("Prototype Creates Distinct Instances", func(t *testing.T) {
+			c := New(Prototype())
+
+			type Service struct {
+				ID int
+			}
+
+			c.Provide(func() *Service {
+				return &Service{}
+			})
+
+			first, err := c.resolve(reflect.TypeOf(&Service{}), nil)
+			assert.NilError(t, err)
+
+			second, err := c.resolve(reflect.TypeOf(&Service{}), nil)
+			assert.NilError(t, err)
+
+			assert.Assert(t, first.Pointer() != second.Pointer())
+			assert.Equal(t, len(c.instances), 0)
+		})
+
 		t.Run("Hooks Injection", func(t *testing.T) {
 			c := New()
 
@@ -126,6 +154,93 @@ func TestContainer(t *testing.T) {
 
 	})
 
+	t.Run("Disable", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Disable and enable a provider", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			c.Disable(0)
+			_, got := c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorIs(t, got, errs.DependencyResolutionError{TypeName: "int"})
+
+			c.Enable(0)
+			val, err := c.resolve(reflect.TypeOf(0), nil)
+			assert.NilError(t, err)
+			assert.Equal(t, val.Int(), int64(42))
+
+			c.Disable(0)
+			_, got = c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorIs(t, got, errs.DependencyResolutionError{TypeName: "int"})
+		})
+
+		t.Run("Disabled concretes are skipped by bindings", func(t *testing.T) {
+			c := New()
+			c.ProvideAs(func() *strings.Builder { return &strings.Builder{} }, new(fmt.Stringer))
+			c.ProvideAs(func() *net.IPNet { return &net.IPNet{} }, new(fmt.Stringer))
+
+			c.Disable((*strings.Builder)(nil))
+			val, err := c.resolve(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+			assert.NilError(t, err)
+
+			_, ok := val.Interface().(*net.IPNet)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("Disabled keyed factories are not aggregated", func(t *testing.T) {
+			c := New()
+			c.ProvideKeyed("primary", func() string { return "db-1" })
+
+			c.Disable("")
+			_, got := c.resolve(reflect.TypeOf(map[string]string{}), nil)
+			assert.ErrorContains(t, got, "failed to resolve dependency")
+		})
+	})
+
+	t.Run("ResolveType", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Successful resolution", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			val, err := c.ResolveType(reflect.TypeOf(0))
+
+			assert.NilError(t, err)
+			assert.Equal(t, val.Int(), int64(42))
+		})
+
+		t.Run("Unresolved dependency", func(t *testing.T) {
+			c := New()
+			_, got := c.ResolveType(reflect.TypeOf(0.0))
+			expected := errs.DependencyResolutionError{TypeName: "float64"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Seeded stack detects cycles", func(t *testing.T) {
+			c := New()
+			c.Provide(func(i int) string { return fmt.Sprint(i) })
+			c.Provide(func() int { return 42 })
+			stack := []reflect.Type{reflect.TypeOf(0)}
+			_, got := c.ResolveTypeWithStack(reflect.TypeOf(""), stack)
+			expected := errs.CyclicDependencyError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Seeded stack is not modified", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			stack := make([]reflect.Type, 1, 4)
+			stack[0] = reflect.TypeOf("")
+			_, err := c.ResolveTypeWithStack(reflect.TypeOf(0), stack)
+
+			assert.NilError(t, err)
+			assert.Equal(t, stack[:cap(stack)][1], nil)
+		})
+	})
+
 	t.Run("Provide", func(t *testing.T) {
 		t.Parallel()
 
@@ -181,157 +296,1726 @@ func TestContainer(t *testing.T) {
 		})
 	})
 
-	t.Run("Run", func(t *testing.T) {
+	t.Run("As", func(t *testing.T) {
 		t.Parallel()
 
-		t.Run("Not a function", func(t *testing.T) {
+		t.Run("Interface resolves the bound concrete", func(t *testing.T) {
 			c := New()
-			got := c.Run("not a function")
-			expected := errs.NotAFunctionError{}
+			c.Provide(func() *strings.Builder { return &strings.Builder{} }, As(new(fmt.Stringer)))
 
-			assert.ErrorIs(t, got, expected)
+			var builder *strings.Builder
+			var stringer fmt.Stringer
+			err := c.Run(func(b *strings.Builder, s fmt.Stringer) {
+				builder, stringer = b, s
+			})
+
+			assert.NilError(t, err)
+			assert.Equal(t, stringer.(*strings.Builder), builder)
 		})
 
-		t.Run("Invalid return", func(t *testing.T) {
+		t.Run("Dependencies of the concrete can be provided after the binding", func(t *testing.T) {
 			c := New()
-			got := c.Run(func() (int, string) { return 0, "" })
-			expected := errs.InvalidFactoryReturnError{NumReturns: 2}
+			err := c.Provide(func(ip net.IP, mask net.IPMask) *net.IPNet {
+				return &net.IPNet{IP: ip, Mask: mask}
+			}, As(new(fmt.Stringer)))
+			assert.NilError(t, err)
+			assert.ErrorContains(t, c.Validate(), "failed to resolve dependency for type IP")
 
-			assert.ErrorIs(t, got, expected)
+			c.Provide(func() net.IP { return net.IPv4(10, 0, 0, 1) })
+			c.Provide(func() net.IPMask { return net.CIDRMask(8, 32) })
+
+			err = c.Run(func(s fmt.Stringer) {
+				assert.Equal(t, s.String(), "10.0.0.1/8")
+			})
+			assert.NilError(t, err)
 		})
 
-		t.Run("Function returns a non-error value", func(t *testing.T) {
+		t.Run("ProvideAs", func(t *testing.T) {
 			c := New()
-			got := c.Run(func() int { return 42 })
-			expected := errs.UnexpectedReturnTypeError{TypeName: "int"}
+			err := c.ProvideAs(func() *strings.Builder { return &strings.Builder{} }, new(fmt.Stringer))
+			assert.NilError(t, err)
 
-			assert.ErrorIs(t, got, expected)
+			val, err := c.resolve(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+			assert.NilError(t, err)
+
+			_, ok := val.Interface().(*strings.Builder)
+			assert.Assert(t, ok)
 		})
 
-		t.Run("Successful execution", func(t *testing.T) {
+		t.Run("Ambiguous interface", func(t *testing.T) {
 			c := New()
-			c.Provide(func() int { return 42 })
+			c.Provide(func() *strings.Builder { return &strings.Builder{} }, As(new(fmt.Stringer)))
+			c.ProvideAs(func() *net.IPNet { return &net.IPNet{} }, new(fmt.Stringer))
 
-			err := c.Run(func(i int) error {
-				if i != 42 {
-					return fmt.Errorf("expected 42, got %d", i)
-				}
-				return nil
-			})
+			_, got := c.resolve(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+			expected := errs.AmbiguousDependencyError{
+				TypeName:   "Stringer",
+				Candidates: []string{"*strings.Builder", "*net.IPNet"},
+			}
 
-			assert.NilError(t, err)
+			assert.ErrorIs(t, got, expected)
+			assert.ErrorContains(t, got, "candidates are *strings.Builder, *net.IPNet")
 		})
 
-		t.Run("Function returns a error", func(t *testing.T) {
+		t.Run("Interface not implemented", func(t *testing.T) {
 			c := New()
-			c.Provide(func() int { return 42 })
-			err := c.Run(func(i int) error {
-				return fmt.Errorf("some error")
-			})
+			got := c.Provide(func() int { return 42 }, As(new(fmt.Stringer)))
+			expected := errs.InterfaceNotImplementedError{TypeName: "int", InterfaceName: "Stringer"}
 
-			assert.ErrorContains(t, err, "some error")
+			assert.ErrorIs(t, got, expected)
 		})
 
-		t.Run("Dependency resolution error", func(t *testing.T) {
+		t.Run("Not an interface", func(t *testing.T) {
 			c := New()
-			got := c.Run(func(f float64) error { return nil })
-			expected := errs.DependencyResolutionError{TypeName: "float64"}
+			got := c.Provide(func() int { return 42 }, As(0))
+			expected := errs.NotAnInterfaceError{TypeName: "int"}
 
 			assert.ErrorIs(t, got, expected)
 		})
+	})
+
+	t.Run("InterfaceFallback", func(t *testing.T) {
+		t.Parallel()
+
+		type Embedding struct {
+			fmt.Stringer
+		}
+
+		stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+		t.Run("Embedded interface is satisfied by the concrete", func(t *testing.T) {
+			c := New(InterfaceFallback())
+			c.Provide(func() *Embedding { return &Embedding{Stringer: &strings.Builder{}} })
+
+			val, err := c.resolve(stringerType, nil)
+			assert.NilError(t, err)
+
+			_, ok := val.Interface().(*Embedding)
+			assert.Assert(t, ok)
+		})
 
-		t.Run("Successful Execution with Hooks", func(t *testing.T) {
+		t.Run("Disabled without the option", func(t *testing.T) {
 			c := New()
+			c.Provide(func() *Embedding { return &Embedding{Stringer: &strings.Builder{}} })
 
-			started := false
-			stopped := false
+			_, got := c.resolve(stringerType, nil)
+			assert.ErrorIs(t, got, errs.DependencyResolutionError{TypeName: "Stringer"})
+		})
 
-			c.Provide(func(h Hooks) int {
-				h.OnStart(func() error {
-					started = true
-					return nil
-				})
+		t.Run("Bindings take precedence", func(t *testing.T) {
+			c := New(InterfaceFallback())
+			c.Provide(func() *Embedding { return &Embedding{Stringer: &strings.Builder{}} })
+			c.ProvideAs(func() *net.IPNet { return &net.IPNet{} }, new(fmt.Stringer))
 
-				h.OnStop(func() error {
-					stopped = true
-					return nil
-				})
+			val, err := c.resolve(stringerType, nil)
+			assert.NilError(t, err)
 
-				return 42
+			_, ok := val.Interface().(*net.IPNet)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("Several implementations", func(t *testing.T) {
+			c := New(InterfaceFallback())
+			c.Provide(func() *Embedding { return &Embedding{Stringer: &strings.Builder{}} })
+			c.Provide(func() *strings.Builder { return &strings.Builder{} })
+
+			_, got := c.resolve(stringerType, nil)
+			assert.ErrorContains(t, got, "ambiguous dependency for type Stringer")
+		})
+	})
+
+	t.Run("AutoCollectInterfaces", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Collects implementations in registration order", func(t *testing.T) {
+			c := New(AutoCollectInterfaces())
+			c.Provide(func() *strings.Builder { return &strings.Builder{} })
+			c.Provide(func() int { return 42 })
+			c.Provide(func() *net.IPNet { return &net.IPNet{} })
+
+			var collected []fmt.Stringer
+			err := c.Run(func(stringers []fmt.Stringer) {
+				collected = stringers
 			})
+			assert.NilError(t, err)
+			assert.Equal(t, len(collected), 2)
 
-			err := c.Run(func(number int) {})
+			_, ok := collected[0].(*strings.Builder)
+			assert.Assert(t, ok)
+
+			_, ok = collected[1].(*net.IPNet)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("Empty slice without implementations", func(t *testing.T) {
+			c := New(AutoCollectInterfaces())
+			c.Provide(func() int { return 42 })
 
+			val, err := c.resolve(reflect.TypeOf([]fmt.Stringer{}), nil)
 			assert.NilError(t, err)
-			assert.Assert(t, started)
-			assert.Assert(t, stopped)
+			assert.Equal(t, val.Len(), 0)
 		})
 
-		t.Run("Error in OnStart Hook", func(t *testing.T) {
+		t.Run("Disabled without the option", func(t *testing.T) {
 			c := New()
+			c.Provide(func() *strings.Builder { return &strings.Builder{} })
 
-			c.Provide(func(h Hooks) int {
-				h.OnStart(func() error {
-					return errors.New("start error")
-				})
+			_, got := c.resolve(reflect.TypeOf([]fmt.Stringer{}), nil)
+			assert.ErrorIs(t, got, errs.DependencyResolutionError{TypeName: ""})
+		})
 
-				return 42
-			})
+		t.Run("Provided slices take precedence", func(t *testing.T) {
+			c := New(AutoCollectInterfaces())
+			c.Provide(func() *strings.Builder { return &strings.Builder{} })
+			c.Provide(func() []fmt.Stringer { return nil })
 
-			err := c.Run(func(number int) {})
-			assert.ErrorContains(t, err, "start error")
+			val, err := c.resolve(reflect.TypeOf([]fmt.Stringer{}), nil)
+			assert.NilError(t, err)
+			assert.Assert(t, val.IsNil())
 		})
+	})
 
-		t.Run("Error in OnStop Hook", func(t *testing.T) {
-			c := New()
+	t.Run("Strict", func(t *testing.T) {
+		t.Parallel()
 
-			c.Provide(func(h Hooks) int {
-				h.OnStop(func() error {
-					return errors.New("stop error")
-				})
+		t.Run("Flags an interface factory when the concrete type is requested", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := New(Strict(), WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+			c.Provide(func() fmt.Stringer { return &net.IPNet{} })
+			assert.Assert(t, strings.Contains(buffer.String(), "factory returns an interface"))
+			assert.Assert(t, strings.Contains(buffer.String(), "type=fmt.Stringer"))
 
-				return 42
-			})
+			err := c.Run(func(*net.IPNet) {})
+			assert.ErrorIs(t, err, errs.ProvidedAsError{TypeName: "*net.IPNet", ProvidedName: "fmt.Stringer"})
+		})
 
-			err := c.Run(func(number int) {})
-			assert.ErrorContains(t, err, "stop error")
+		t.Run("Flags a mismatched pointer-ness", func(t *testing.T) {
+			type Config struct{}
+
+			c := New(Strict())
+			c.Provide(func() Config { return Config{} })
+
+			_, err := c.resolve(reflect.TypeOf(&Config{}), nil)
+			assert.ErrorIs(t, err, errs.ProvidedAsError{TypeName: "*zeus.Config", ProvidedName: "zeus.Config"})
+		})
+
+		t.Run("Leaves unrelated types alone", func(t *testing.T) {
+			c := New(Strict(), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			c.Provide(func() fmt.Stringer { return &net.IPNet{} })
+
+			_, err := c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "int"})
+		})
+
+		t.Run("Disabled by default", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := New(WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+			c.Provide(func() fmt.Stringer { return &net.IPNet{} })
+			assert.Equal(t, buffer.Len(), 0)
+
+			_, err := c.resolve(reflect.TypeOf(&net.IPNet{}), nil)
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: ""})
 		})
 	})
 
-	t.Run("Merge", func(t *testing.T) {
-		t.Run("Merge without conflicts", func(t *testing.T) {
-			containerA := New()
-			containerB := New()
+	t.Run("NilDefaults", func(t *testing.T) {
+		t.Parallel()
 
-			containerA.Provide(func() string { return "Hello" })
-			containerB.Provide(func() int { return 42 })
+		t.Run("Allowed interfaces resolve to nil", func(t *testing.T) {
+			c := New(NilDefaults((*fmt.Stringer)(nil)))
 
-			err := containerA.Merge(containerB)
+			called := false
+			err := c.Run(func(s fmt.Stringer) {
+				called = true
+				assert.Assert(t, s == nil)
+			})
 			assert.NilError(t, err)
+			assert.Assert(t, called)
+			assert.NilError(t, c.Validate())
 		})
 
-		t.Run("Merge with identical factories", func(t *testing.T) {
-			containerA := New()
-			containerB := New()
+		t.Run("Other interfaces still fail", func(t *testing.T) {
+			c := New(NilDefaults((*fmt.Stringer)(nil), 42))
 
-			factory := func() string { return "Hello" }
+			err := c.Run(func(r io.Reader) {})
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "Reader"})
+		})
 
-			containerA.Provide(factory)
-			containerB.Provide(factory)
+		t.Run("Provided interfaces take precedence", func(t *testing.T) {
+			c := New(NilDefaults((*fmt.Stringer)(nil)))
+			c.Provide(func() fmt.Stringer { return &net.IPNet{} })
 
-			err := containerA.Merge(containerB)
+			err := c.Run(func(s fmt.Stringer) { assert.Assert(t, s != nil) })
 			assert.NilError(t, err)
 		})
+	})
 
-		t.Run("Merge with conflicting factories", func(t *testing.T) {
-			containerA := New()
-			containerB := New()
+	t.Run("CopyOnResolve", func(t *testing.T) {
+		t.Parallel()
 
-			containerA.Provide(func() string { return "Hello" })
-			containerB.Provide(func() string { return "World" })
+		type settings struct {
+			Name string
+			Port int
+		}
+
+		t.Run("Consumers of a value get independent copies", func(t *testing.T) {
+			c := New(CopyOnResolve())
+			calls := 0
+			c.Provide(func() settings {
+				calls++
+				return settings{Name: "primary", Port: 8080}
+			})
 
-			err := containerA.Merge(containerB)
-			assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "string"})
+			first, err := c.resolve(reflect.TypeOf(settings{}), nil)
+			assert.NilError(t, err)
+			second, err := c.resolve(reflect.TypeOf(settings{}), nil)
+			assert.NilError(t, err)
+
+			first.Field(0).SetString("mutated")
+			first.Field(1).SetInt(1)
+
+			assert.Equal(t, second.Interface().(settings), settings{Name: "primary", Port: 8080})
+			resolved, err := Resolve[settings](c)
+			assert.NilError(t, err)
+			assert.Equal(t, resolved, settings{Name: "primary", Port: 8080})
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Functions see the cached value", func(t *testing.T) {
+			c := New(CopyOnResolve())
+			c.Provide(func() settings { return settings{Name: "primary"} })
+
+			err := c.Run(func(s settings) { s.Name = "mutated" })
+			assert.NilError(t, err)
+
+			err = c.Run(func(s settings) { assert.Equal(t, s.Name, "primary") })
+			assert.NilError(t, err)
+		})
+
+		t.Run("Pointers are shared", func(t *testing.T) {
+			c := New(CopyOnResolve())
+			c.Provide(func() *settings { return &settings{Name: "primary"} })
+
+			var first, second *settings
+			err := c.Run(func(a *settings, b *settings) { first, second = a, b })
+			assert.NilError(t, err)
+			assert.Assert(t, first == second)
 		})
 	})
+
+	t.Run("MultiReturn", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Every returned type is resolvable", func(t *testing.T) {
+			c := New()
+			calls := 0
+			err := c.Provide(func() (string, int) {
+				calls++
+				return "localhost", 8080
+			}, MultiReturn())
+			assert.NilError(t, err)
+
+			host, err := c.resolve(reflect.TypeOf(""), nil)
+			assert.NilError(t, err)
+			assert.Equal(t, host.Interface(), "localhost")
+
+			port, err := c.resolve(reflect.TypeOf(0), nil)
+			assert.NilError(t, err)
+			assert.Equal(t, port.Interface(), 8080)
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Trailing error", func(t *testing.T) {
+			c := New()
+			expected := errors.New("unreachable")
+			c.Provide(func() (string, int, error) { return "", 0, expected }, MultiReturn())
+
+			_, got := c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Duplicated return types", func(t *testing.T) {
+			c := New()
+			got := c.Provide(func() (int, int) { return 0, 0 }, MultiReturn())
+			expected := errs.UnexpectedReturnTypeError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Conflicts with an existing provider", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 0 })
+			got := c.Provide(func() (string, int) { return "", 0 }, MultiReturn())
+			expected := errs.FactoryAlreadyProvidedError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+	})
+
+	t.Run("ProvideConverter", func(t *testing.T) {
+		t.Parallel()
+
+		type DBConfig struct{ DSN string }
+		type Config struct{ Database DBConfig }
+		type PoolConfig struct{ DSN string }
+
+		t.Run("Invalid converter", func(t *testing.T) {
+			c := New()
+			got := c.ProvideConverter(func(a, b Config) DBConfig { return a.Database })
+			expected := errs.InvalidConverterError{TypeName: "DBConfig"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Converts a provided type", func(t *testing.T) {
+			c := New()
+			c.Provide(func() Config { return Config{Database: DBConfig{DSN: "postgres://"}} })
+			c.ProvideConverter(func(cfg Config) DBConfig { return cfg.Database })
+
+			var got DBConfig
+			err := c.Run(func(cfg DBConfig) { got = cfg })
+
+			assert.NilError(t, err)
+			assert.Equal(t, got.DSN, "postgres://")
+		})
+
+		t.Run("Chained converters", func(t *testing.T) {
+			c := New()
+			c.Provide(func() Config { return Config{Database: DBConfig{DSN: "postgres://"}} })
+			c.ProvideConverter(
+				func(cfg Config) DBConfig { return cfg.Database },
+				func(cfg DBConfig) (PoolConfig, error) { return PoolConfig(cfg), nil },
+			)
+
+			val, err := c.resolve(reflect.TypeOf(PoolConfig{}), nil)
+
+			assert.NilError(t, err)
+			assert.Equal(t, val.Interface().(PoolConfig).DSN, "postgres://")
+		})
+
+		t.Run("Factory takes precedence", func(t *testing.T) {
+			c := New()
+			c.Provide(func() Config { return Config{Database: DBConfig{DSN: "postgres://"}} })
+			c.Provide(func() DBConfig { return DBConfig{DSN: "mysql://"} })
+			c.ProvideConverter(func(cfg Config) DBConfig { return cfg.Database })
+
+			val, err := c.resolve(reflect.TypeOf(DBConfig{}), nil)
+
+			assert.NilError(t, err)
+			assert.Equal(t, val.Interface().(DBConfig).DSN, "mysql://")
+		})
+
+		t.Run("Ambiguous converters", func(t *testing.T) {
+			c := New()
+			c.ProvideConverter(
+				func(cfg Config) DBConfig { return cfg.Database },
+				func(cfg PoolConfig) DBConfig { return DBConfig(cfg) },
+			)
+
+			_, got := c.resolve(reflect.TypeOf(DBConfig{}), nil)
+			expected := errs.AmbiguousDependencyError{
+				TypeName:   "DBConfig",
+				Candidates: []string{"zeus.Config", "zeus.PoolConfig"},
+			}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Unresolved source", func(t *testing.T) {
+			c := New()
+			c.ProvideConverter(func(cfg Config) DBConfig { return cfg.Database })
+
+			_, got := c.resolve(reflect.TypeOf(DBConfig{}), nil)
+			expected := errs.DependencyResolutionError{TypeName: "Config"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+	})
+
+	t.Run("ErrSkip", func(t *testing.T) {
+		t.Parallel()
+
+		type Config struct{ DSN string }
+		type DBConfig struct{ DSN string }
+
+		t.Run("Falls through to a converter", func(t *testing.T) {
+			c := New()
+			c.Provide(func() Config { return Config{DSN: "postgres://"} })
+			c.Provide(func() (DBConfig, error) { return DBConfig{}, ErrSkip })
+			c.ProvideConverter(func(cfg Config) DBConfig { return DBConfig(cfg) })
+
+			var got DBConfig
+			err := c.Run(func(cfg DBConfig) { got = cfg })
+
+			assert.NilError(t, err)
+			assert.Equal(t, got.DSN, "postgres://")
+		})
+
+		t.Run("Falls through to a binding", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (fmt.Stringer, error) { return nil, ErrSkip })
+			c.ProvideAs(func() *strings.Builder { return &strings.Builder{} }, new(fmt.Stringer))
+
+			val, err := c.resolve(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+			assert.NilError(t, err)
+
+			_, ok := val.Interface().(*strings.Builder)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("Hooks of the skipping factory are dropped", func(t *testing.T) {
+			c := New()
+			stopped := false
+			c.Provide(func() Config { return Config{DSN: "postgres://"} })
+			c.Provide(func(h Hooks) (DBConfig, error) {
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+				return DBConfig{}, ErrSkip
+			})
+			c.ProvideConverter(func(cfg Config) DBConfig { return DBConfig(cfg) })
+
+			assert.NilError(t, c.Build())
+			assert.NilError(t, c.Close())
+			assert.Assert(t, !stopped)
+		})
+
+		t.Run("No alternative", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (DBConfig, error) { return DBConfig{}, ErrSkip })
+
+			_, got := c.resolve(reflect.TypeOf(DBConfig{}), nil)
+			expected := errs.DependencyResolutionError{TypeName: "DBConfig"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Dependents see the resolution error", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (DBConfig, error) { return DBConfig{}, ErrSkip })
+
+			err := c.Run(func(cfg DBConfig) {})
+
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "DBConfig"})
+			assert.Assert(t, !errors.Is(err, ErrSkip))
+		})
+	})
+
+	t.Run("ProvideKeyed", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Not a function", func(t *testing.T) {
+			c := New()
+			got := c.ProvideKeyed("key", "string")
+			expected := errs.NotAFunctionError{}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Duplicated key", func(t *testing.T) {
+			c := New()
+			c.ProvideKeyed("primary", func() string { return "db-1" })
+			got := c.ProvideKeyed("primary", func() string { return "db-2" })
+			expected := errs.KeyAlreadyProvidedError{Key: "primary", TypeName: "string"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Map injection", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 5432 })
+			c.ProvideKeyed("primary", func() string { return "db-1" })
+			c.ProvideKeyed("replica", func() string { return "db-2" })
+			c.ProvideKeyed("analytics", func(port int) string { return fmt.Sprintf("db-3:%d", port) })
+
+			var got map[string]string
+			err := c.Run(func(endpoints map[string]string) {
+				got = endpoints
+			})
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, map[string]string{
+				"primary":   "db-1",
+				"replica":   "db-2",
+				"analytics": "db-3:5432",
+			})
+		})
+
+		t.Run("Map factory takes precedence", func(t *testing.T) {
+			c := New()
+			c.ProvideKeyed("primary", func() string { return "db-1" })
+			c.Provide(func() map[string]string { return map[string]string{"other": "db-9"} })
+
+			val, err := c.resolve(reflect.TypeOf(map[string]string{}), nil)
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, val.Interface(), map[string]string{"other": "db-9"})
+		})
+
+		t.Run("Keyed factory returns a error", func(t *testing.T) {
+			c := New()
+			c.ProvideKeyed("primary", func() (string, error) { return "", fmt.Errorf("some error") })
+			_, err := c.resolve(reflect.TypeOf(map[string]string{}), nil)
+
+			assert.ErrorContains(t, err, "some error")
+		})
+	})
+
+	t.Run("ConstructTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Slow factory times out", func(t *testing.T) {
+			c := New()
+			release := make(chan struct{})
+			finished := make(chan struct{})
+			calls, stops := atomic.Int32{}, atomic.Int32{}
+
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { stops.Add(1); return nil })
+
+				if calls.Add(1) == 1 {
+					defer close(finished)
+					<-release
+					return 1
+				}
+
+				return 2
+			}, ConstructTimeout(10*time.Millisecond))
+
+			_, got := c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorIs(t, got, errs.ConstructTimeoutError{TypeName: "int", Timeout: 10 * time.Millisecond})
+
+			close(release)
+			<-finished
+
+			err := c.Run(func(i int) { assert.Equal(t, i, 2) })
+			assert.NilError(t, err)
+			assert.Equal(t, calls.Load(), int32(2))
+			assert.Equal(t, stops.Load(), int32(1))
+		})
+
+		t.Run("Abandoned result is not cached", func(t *testing.T) {
+			c := New()
+			release := make(chan struct{})
+			done := make(chan struct{})
+
+			c.Provide(func() (string, int) {
+				defer close(done)
+				<-release
+				return "late", 1
+			}, MultiReturn(), ConstructTimeout(10*time.Millisecond))
+
+			_, got := c.resolve(reflect.TypeOf(""), nil)
+			assert.ErrorContains(t, got, "construction of type string timed out")
+
+			close(release)
+			<-done
+			time.Sleep(time.Millisecond)
+
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			assert.Equal(t, len(c.instances), 0)
+			assert.Equal(t, len(c.tuples), 0)
+		})
+
+		t.Run("Fast factory is unaffected", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (int, error) { return 42, nil }, ConstructTimeout(time.Second))
+
+			val, err := c.resolve(reflect.TypeOf(0), nil)
+			assert.NilError(t, err)
+			assert.Equal(t, val.Int(), int64(42))
+		})
+
+		t.Run("Errors are returned", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (int, error) { return 0, errors.New("factory error") }, ConstructTimeout(time.Second))
+
+			_, got := c.resolve(reflect.TypeOf(0), nil)
+			assert.ErrorContains(t, got, "factory error")
+		})
+	})
+
+	t.Run("WithCleanup", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Cleanup receives the instance on stop", func(t *testing.T) {
+			c := New()
+			var built, cleaned *strings.Builder
+
+			err := c.Provide(
+				func() *strings.Builder { built = &strings.Builder{}; return built },
+				WithCleanup(func(b *strings.Builder) error { cleaned = b; return nil }),
+			)
+			assert.NilError(t, err)
+
+			err = c.Run(func(b *strings.Builder) {
+				assert.Assert(t, cleaned == nil)
+			})
+
+			assert.NilError(t, err)
+			assert.Assert(t, built != nil)
+			assert.Equal(t, cleaned, built)
+		})
+
+		t.Run("Cleanup errors are reported", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 }, WithCleanup(func(i int) error { return errors.New("cleanup error") }))
+
+			err := c.Run(func(i int) {})
+			assert.ErrorContains(t, err, "cleanup error")
+		})
+
+		t.Run("Cleanup of an unresolved type does not run", func(t *testing.T) {
+			c := New()
+			cleaned := false
+			c.Provide(func() int { return 42 }, WithCleanup(func(i int) { cleaned = true }))
+
+			err := c.Run(func() {})
+			assert.NilError(t, err)
+			assert.Assert(t, !cleaned)
+		})
+
+		t.Run("Cleanup must take the factory type", func(t *testing.T) {
+			c := New()
+			got := c.Provide(func() int { return 42 }, WithCleanup(func(s string) error { return nil }))
+			expected := errs.InvalidCleanupError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		t.Parallel()
+
+		type Handler func() string
+
+		t.Run("Not a function", func(t *testing.T) {
+			c := New()
+			got := c.Decorate("string")
+			expected := errs.NotAFunctionError{}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Decorator without inner parameter", func(t *testing.T) {
+			c := New()
+			got := c.Decorate(func() Handler { return nil })
+			expected := errs.InvalidDecoratorError{TypeName: "Handler"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Two-layer decorator chain", func(t *testing.T) {
+			c := New()
+			c.Provide(func() Handler {
+				return func() string { return "base" }
+			})
+			c.ProvideDecorator(func(inner Handler) Handler {
+				return func() string { return "auth(" + inner() + ")" }
+			})
+			c.Decorate(func(inner Handler, prefix string) Handler {
+				return func() string { return prefix + "(" + inner() + ")" }
+			})
+			c.Provide(func() string { return "log" })
+
+			var got string
+			err := c.Run(func(h Handler) {
+				got = h()
+			})
+
+			assert.NilError(t, err)
+			assert.Equal(t, got, "log(auth(base))")
+		})
+
+		t.Run("Decorated value is cached", func(t *testing.T) {
+			c := New()
+			calls := 0
+			c.Provide(func() int { return 1 })
+			c.Decorate(func(inner int) int {
+				calls++
+				return inner * 10
+			})
+
+			first, _ := c.resolve(reflect.TypeOf(0), nil)
+			second, _ := c.resolve(reflect.TypeOf(0), nil)
+
+			assert.Equal(t, first.Int(), int64(10))
+			assert.Equal(t, second.Int(), int64(10))
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Decorator returns a error", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 1 })
+			c.Decorate(func(inner int) (int, error) {
+				return 0, fmt.Errorf("some error")
+			})
+
+			_, err := c.resolve(reflect.TypeOf(0), nil)
+
+			assert.ErrorContains(t, err, "some error")
+		})
+
+		t.Run("Decorator without base factory", func(t *testing.T) {
+			c := New()
+			c.Decorate(func(inner Handler) Handler { return inner })
+
+			_, got := c.resolve(reflect.TypeOf(Handler(nil)), nil)
+			expected := errs.DependencyResolutionError{TypeName: "Handler"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Decorators are applied by decreasing priority", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "base" })
+			c.Decorate(func(inner string) string { return inner + "+low" }, Priority(-1))
+			c.Decorate(func(inner string) string { return inner + "+default" })
+			c.Decorate(func(inner string) string { return inner + "+high" }, Priority(10))
+			c.Decorate(func(inner string) string { return inner + "+tied" })
+
+			got, err := c.resolve(reflect.TypeOf(""), nil)
+
+			assert.NilError(t, err)
+			assert.Equal(t, got.String(), "base+high+default+tied+low")
+		})
+
+		t.Run("Removed decorators no longer apply", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "base" })
+			c.Provide(func(s string) fmt.Stringer { return net.ParseIP("127.0.0.1") })
+			c.Decorate(func(inner string) string { return inner + "+traced" })
+
+			before, _ := c.resolve(reflect.TypeOf(""), nil)
+			c.resolve(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), nil)
+
+			err := c.RemoveDecorators("")
+
+			assert.NilError(t, err)
+			assert.Equal(t, before.String(), "base+traced")
+			_, cached := c.cached(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+			assert.Assert(t, !cached)
+
+			after, _ := c.resolve(reflect.TypeOf(""), nil)
+
+			assert.Equal(t, after.String(), "base")
+		})
+
+		t.Run("Removing decorators of a frozen container", func(t *testing.T) {
+			c := New()
+			c.Freeze()
+
+			assert.ErrorIs(t, c.RemoveDecorators(""), errs.FrozenError{})
+		})
+	})
+
+	t.Run("Warm", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Cancellation stops launching constructions", func(t *testing.T) {
+			c := New(MaxConcurrency(1))
+
+			type Database struct{}
+			type Cache struct{}
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			defer close(release)
+
+			var caches atomic.Int32
+
+			c.Provide(func() *Database {
+				close(started)
+				<-release
+				return &Database{}
+			})
+			c.Provide(func() *Cache {
+				caches.Add(1)
+				return &Cache{}
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-started
+				cancel()
+			}()
+
+			begin := time.Now()
+			err := c.WarmContext(ctx, (*Database)(nil), (*Cache)(nil))
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Assert(t, time.Since(begin) < time.Second)
+			assert.Equal(t, caches.Load(), int32(0))
+		})
+
+		t.Run("Completed warm ignores a later cancellation", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			ctx, cancel := context.WithCancel(context.Background())
+			assert.NilError(t, c.WarmContext(ctx, 0))
+			cancel()
+
+			assert.DeepEqual(t, typeNames(c.CachedTypes()), []string{"int"})
+		})
+
+		t.Run("Builds each type once", func(t *testing.T) {
+			c := New()
+
+			type Config struct{ Name string }
+			type Database struct{ Config *Config }
+			type Cache struct{ Config *Config }
+			type Queue struct{ Config *Config }
+
+			var configs, databases, caches, queues atomic.Int32
+
+			c.Provide(func() *Config {
+				configs.Add(1)
+				time.Sleep(time.Millisecond)
+				return &Config{Name: "app"}
+			})
+			c.Provide(func(cfg *Config) *Database {
+				databases.Add(1)
+				return &Database{Config: cfg}
+			})
+			c.Provide(func(cfg *Config) *Cache {
+				caches.Add(1)
+				return &Cache{Config: cfg}
+			})
+			c.Provide(func(cfg *Config) *Queue {
+				queues.Add(1)
+				return &Queue{Config: cfg}
+			})
+
+			err := c.Warm((*Database)(nil), (*Cache)(nil), (*Queue)(nil))
+			assert.NilError(t, err)
+
+			err = c.Run(func(db *Database, cache *Cache, queue *Queue) {
+				assert.Equal(t, db.Config, cache.Config)
+				assert.Equal(t, cache.Config, queue.Config)
+			})
+			assert.NilError(t, err)
+
+			assert.Equal(t, configs.Load(), int32(1))
+			assert.Equal(t, databases.Load(), int32(1))
+			assert.Equal(t, caches.Load(), int32(1))
+			assert.Equal(t, queues.Load(), int32(1))
+		})
+
+		t.Run("Aggregates errors", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (int, error) { return 0, fmt.Errorf("int error") })
+			c.Provide(func() (string, error) { return "", fmt.Errorf("string error") })
+
+			err := c.Warm(0, "")
+
+			assert.ErrorContains(t, err, "int error")
+			assert.ErrorContains(t, err, "string error")
+		})
+
+		t.Run("Interface sample", func(t *testing.T) {
+			c := New()
+			c.Provide(func() fmt.Stringer { return &strings.Builder{} })
+
+			err := c.Warm((*fmt.Stringer)(nil))
+			assert.NilError(t, err)
+
+			_, exists := c.instances[reflect.TypeOf((*fmt.Stringer)(nil)).Elem()]
+			assert.Assert(t, exists)
+		})
+
+		t.Run("Concurrency limit", func(t *testing.T) {
+			c := New(MaxConcurrency(2))
+			var running, peak atomic.Int32
+
+			slow := func() {
+				current := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					observed := peak.Load()
+					if current <= observed || peak.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+			}
+
+			c.Provide(
+				func() int8 { slow(); return 0 },
+				func() int16 { slow(); return 0 },
+				func() int32 { slow(); return 0 },
+				func() int64 { slow(); return 0 },
+				func() uint8 { slow(); return 0 },
+				func() uint16 { slow(); return 0 },
+				func() uint32 { slow(); return 0 },
+				func() uint64 { slow(); return 0 },
+			)
+
+			err := c.Warm(int8(0), int16(0), int32(0), int64(0), uint8(0), uint16(0), uint32(0), uint64(0))
+
+			assert.NilError(t, err)
+			assert.Assert(t, peak.Load() <= 2)
+			assert.Assert(t, peak.Load() >= 1)
+		})
+
+		t.Run("Cyclic dependency", func(t *testing.T) {
+			c := New()
+			c.Provide(func(s string) int { return len(s) })
+			c.Provide(func(i int) string { return fmt.Sprint(i) })
+
+			err := c.Warm(0, "")
+
+			assert.ErrorIs(t, err, errs.CyclicDependencyError{TypeName: "int"})
+		})
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("Not a function", func(t *testing.T) {
+			c := New()
+			got := c.Run("not a function")
+			expected := errs.NotAFunctionError{}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Invalid return", func(t *testing.T) {
+			c := New()
+			got := c.Run(func() (int, string) { return 0, "" })
+			expected := errs.InvalidFactoryReturnError{NumReturns: 2}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Function returns a non-error value", func(t *testing.T) {
+			c := New()
+			got := c.Run(func() int { return 42 })
+			expected := errs.UnexpectedReturnTypeError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Successful execution", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			err := c.Run(func(i int) error {
+				if i != 42 {
+					return fmt.Errorf("expected 42, got %d", i)
+				}
+				return nil
+			})
+
+			assert.NilError(t, err)
+		})
+
+		t.Run("Function returns a error", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			err := c.Run(func(i int) error {
+				return fmt.Errorf("some error")
+			})
+
+			assert.ErrorContains(t, err, "some error")
+		})
+
+		t.Run("Dependency resolution error", func(t *testing.T) {
+			c := New()
+			got := c.Run(func(f float64) error { return nil })
+			expected := errs.DependencyResolutionError{TypeName: "float64"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+
+		t.Run("Multiple dependency resolution errors", func(t *testing.T) {
+			c := New()
+			got := c.Run(func(f float64, b bool, u uint) error { return nil })
+
+			es, ok := got.(*errs.ErrorSet)
+			assert.Assert(t, ok)
+			assert.Equal(t, len(es.Errors()), 3)
+			assert.ErrorContains(t, es, "failed to resolve dependency for type float64")
+			assert.ErrorContains(t, es, "failed to resolve dependency for type bool")
+			assert.ErrorContains(t, es, "failed to resolve dependency for type uint")
+		})
+
+		t.Run("Too many parameters under MaxRunParams", func(t *testing.T) {
+			fn := func(i int, s string, f float64) error { return nil }
+			called := false
+
+			c := New(MaxRunParams(2))
+			c.Provide(func() int { return 42 }, func() string { return "Hello" }, func() float64 { return 1 })
+
+			got := c.Run(fn)
+			assert.ErrorIs(t, got, errs.TooManyParametersError{Count: 3, Max: 2})
+			assert.NilError(t, c.Run(func(i int, s string) { called = true }))
+			assert.Assert(t, called)
+
+			c = New()
+			c.Provide(func() int { return 42 }, func() string { return "Hello" }, func() float64 { return 1 })
+
+			assert.NilError(t, c.Run(fn))
+		})
+
+		t.Run("Missing error return under RequireRunError", func(t *testing.T) {
+			called := false
+
+			c := New(RequireRunError())
+
+			assert.ErrorIs(t, c.Run(func() { called = true }), errs.MissingErrorReturnError{})
+			assert.Assert(t, !called)
+			assert.NilError(t, c.Run(func() error { return nil }))
+			assert.NilError(t, New().Run(func() {}))
+		})
+
+		t.Run("Successful Execution with Hooks", func(t *testing.T) {
+			c := New()
+
+			started := false
+			stopped := false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error {
+					started = true
+					return nil
+				})
+
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+
+				return 42
+			})
+
+			err := c.Run(func(number int) {})
+
+			assert.NilError(t, err)
+			assert.Assert(t, started)
+			assert.Assert(t, stopped)
+		})
+
+		t.Run("Error in OnStart Hook", func(t *testing.T) {
+			c := New()
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error {
+					return errors.New("start error")
+				})
+
+				return 42
+			})
+
+			err := c.Run(func(number int) {})
+			assert.ErrorContains(t, err, "start error")
+		})
+
+		t.Run("Error in OnStartRetry Hook", func(t *testing.T) {
+			c := New()
+
+			c.Provide(func(h Hooks) int {
+				h.(RetryHooks).OnStartRetry(func() error {
+					return errors.New("still not ready")
+				}, 2, time.Millisecond)
+
+				return 42
+			})
+
+			err := c.Run(func(number int) {})
+			assert.ErrorContains(t, err, "still not ready")
+		})
+
+		t.Run("Panicking factory with RecoverPanics", func(t *testing.T) {
+			c := New(RecoverPanics())
+
+			c.Provide(func() int {
+				var m map[string]int
+				m["boom"] = 1
+				return 42
+			})
+
+			err := c.Run(func(number int) {})
+
+			var panicErr errs.PanicError
+			assert.Assert(t, errors.As(err, &panicErr))
+			assert.Equal(t, panicErr.Source, "factory for type int")
+			assert.ErrorContains(t, err, "assignment to entry in nil map")
+		})
+
+		t.Run("Panicking function with RecoverPanics", func(t *testing.T) {
+			c := New(RecoverPanics())
+			stopped := false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+
+				return 42
+			})
+
+			err := c.Run(func(number int) {
+				panic("boom")
+			})
+
+			assert.ErrorContains(t, err, "panic in function github.com/otoru/zeus.TestContainer")
+			assert.ErrorContains(t, err, "boom")
+			assert.Assert(t, stopped)
+		})
+
+		t.Run("Panicking factory without RecoverPanics", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { panic("boom") })
+
+			defer func() {
+				assert.Equal(t, recover(), "boom")
+			}()
+
+			c.Run(func(number int) {})
+			t.Fatal("expected a panic")
+		})
+
+		t.Run("Hooks of a failing factory are discarded", func(t *testing.T) {
+			c := New()
+			started, stopped := false, false
+
+			c.Provide(func(h Hooks) (int, error) {
+				h.OnStart(func() error {
+					started = true
+					return nil
+				})
+
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+
+				return 0, errors.New("construction error")
+			})
+
+			err := c.Run(func(number int) {})
+			assert.ErrorContains(t, err, "construction error")
+
+			err = c.Run(func() {})
+			assert.NilError(t, err)
+			assert.Assert(t, !started)
+			assert.Assert(t, !stopped)
+		})
+
+		t.Run("Hooks of a failing decorator are discarded", func(t *testing.T) {
+			c := New()
+			stopped := false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+
+				return 42
+			})
+			c.Decorate(func(inner int) (int, error) {
+				return 0, errors.New("decoration error")
+			})
+
+			c.Run(func(number int) {})
+			c.Run(func() {})
+
+			assert.Assert(t, !stopped)
+		})
+
+		t.Run("Error in OnStop Hook", func(t *testing.T) {
+			c := New()
+
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error {
+					return errors.New("stop error")
+				})
+
+				return 42
+			})
+
+			err := c.Run(func(number int) {})
+			assert.ErrorContains(t, err, "stop error")
+		})
+
+		t.Run("Custom errors can be retrieved from the error set", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (int, error) { return 0, &domainError{Code: 42} })
+			c.Provide(func() (string, error) { return "", errors.New("string error") })
+
+			err := c.Run(func(number int, text string) {})
+
+			var target *domainError
+			assert.Assert(t, errors.As(err, &target))
+			assert.Equal(t, target.Code, 42)
+		})
+
+		t.Run("Error strategy keeps the first error", func(t *testing.T) {
+			c := New()
+			first := errors.New("int error")
+			c.Provide(func() (int, error) { return 0, first })
+			c.Provide(func() (string, error) { return "", errors.New("string error") })
+
+			err := c.Run(func(number int, text string) {}, ErrorStrategy(errs.ResultFirst))
+			assert.Equal(t, err, first)
+
+			err = c.Run(func(number int, text string) {}, ErrorStrategy(errs.ResultFirst), OverrideFor(func() bool { return true }))
+			assert.Equal(t, err, first)
+		})
+
+		t.Run("Errors are tagged with their parameter", func(t *testing.T) {
+			c := New()
+			intErr := errors.New("int error")
+			stringErr := errors.New("string error")
+			c.Provide(func() (int, error) { return 0, intErr })
+			c.Provide(func() (string, error) { return "", stringErr })
+
+			err := c.Run(func(number int, text string) {}, TagParameterErrors())
+			assert.ErrorContains(t, err, "parameter 0 of type int: int error")
+			assert.ErrorContains(t, err, "parameter 1 of type string: string error")
+
+			var errorSet *errs.ErrorSet
+			assert.Assert(t, errors.As(err, &errorSet))
+
+			params := map[string]error{}
+			for _, e := range errorSet.Errors() {
+				var param errs.ParameterError
+				assert.Assert(t, errors.As(e, &param))
+				params[param.TypeName] = param.Err
+			}
+			assert.Equal(t, params["int"], intErr)
+			assert.Equal(t, params["string"], stringErr)
+
+			err = c.Run(func(number int, text string) {}, TagParameterErrors(), ErrorStrategy(errs.ResultFirst), OverrideFor(func() bool { return true }))
+			assert.Equal(t, err, errs.ParameterError{Index: 0, TypeName: "int", Err: intErr})
+		})
+
+		t.Run("Errors are ordered by phase", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { return errors.New("first stop error") })
+				h.OnStop(func() error { return errors.New("second stop error") })
+				return 0
+			})
+
+			err := c.Run(func(int) error { return errors.New("function error") })
+
+			var errorSet *errs.ErrorSet
+			assert.Assert(t, errors.As(err, &errorSet))
+			assert.Equal(t, len(errorSet.Errors()), 3)
+			assert.Error(t, err, "function error; first stop error; second stop error")
+		})
+
+		t.Run("Resolution errors are ordered by parameter", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { return errors.New("start error") })
+				return 0
+			})
+
+			err := c.Run(func(f float64, i int, b bool) {})
+			assert.Error(t, err, "failed to resolve dependency for type float64; failed to resolve dependency for type bool")
+
+			err = c.Run(func(i int) error { return errors.New("function error") })
+			assert.Error(t, err, "start error")
+		})
+	})
+
+	t.Run("RunCapture", func(t *testing.T) {
+		t.Run("Captures the injected singletons", func(t *testing.T) {
+			c := New()
+			c.Provide(func() *net.IPNet { return &net.IPNet{} }, func() int { return 42 })
+
+			args, err := c.RunCapture(func(n *net.IPNet, i int) {})
+			assert.NilError(t, err)
+			assert.Equal(t, len(args), 2)
+
+			network := c.instances[reflect.TypeOf(&net.IPNet{})]
+			assert.Equal(t, args[0], network.Interface())
+			assert.Equal(t, args[1], 42)
+		})
+
+		t.Run("Captures the arguments of a failing function", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			args, err := c.RunCapture(func(i int) error { return errors.New("function error") })
+			assert.Error(t, err, "function error")
+			assert.DeepEqual(t, args, []interface{}{42})
+		})
+
+		t.Run("Captures nothing when a parameter is not resolvable", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			args, err := c.RunCapture(func(i int, s string) {})
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "string"})
+			assert.Assert(t, args == nil)
+		})
+	})
+
+	t.Run("RunDry", func(t *testing.T) {
+		t.Run("Runs the hooks without calling the function", func(t *testing.T) {
+			c := New()
+			started, stopped, called := false, false, false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error {
+					started = true
+					return nil
+				})
+
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+
+				return 42
+			})
+
+			err := c.RunDry(func(number int) { called = true })
+			assert.NilError(t, err)
+			assert.Assert(t, started)
+			assert.Assert(t, stopped)
+			assert.Assert(t, !called)
+		})
+
+		t.Run("Returns the hook errors", func(t *testing.T) {
+			c := New()
+
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error {
+					return errors.New("stop error")
+				})
+
+				return 42
+			})
+
+			err := c.RunDry(func(number int) error { return errors.New("function error") })
+			assert.Error(t, err, "stop error")
+		})
+
+		t.Run("Reports unresolvable parameters", func(t *testing.T) {
+			c := New()
+
+			err := c.RunDry(func(s string) {})
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "string"})
+		})
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		t.Run("Merge without conflicts", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() string { return "Hello" })
+			containerB.Provide(func() int { return 42 })
+
+			err := containerA.Merge(containerB)
+			assert.NilError(t, err)
+		})
+
+		t.Run("Merge with identical factories", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			factory := func() string { return "Hello" }
+
+			containerA.Provide(factory)
+			containerB.Provide(factory)
+
+			err := containerA.Merge(containerB)
+			assert.NilError(t, err)
+		})
+
+		t.Run("Merge with conflicting factories", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() string { return "Hello" })
+			containerB.Provide(func() string { return "World" })
+
+			err := containerA.Merge(containerB)
+			assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "string"})
+		})
+	})
+
+	t.Run("MergeAll", func(t *testing.T) {
+		t.Run("Merge without conflicts", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() string { return "Hello" })
+			containerB.Provide(func() int { return 42 })
+
+			err := containerA.MergeAll(containerB)
+			assert.NilError(t, err)
+
+			_, exists := containerA.providers[reflect.TypeOf(0)]
+			assert.Assert(t, exists)
+		})
+
+		t.Run("Merge with every conflict reported", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() string { return "Hello" }, func() int { return 42 })
+			containerB.Provide(func() string { return "World" }, func() int { return 7 }, func() bool { return true })
+
+			err := containerA.MergeAll(containerB)
+
+			es, ok := err.(*errs.ErrorSet)
+			assert.Assert(t, ok)
+			assert.Equal(t, len(es.Errors()), 2)
+			assert.ErrorContains(t, err, "a factory for type int has already been provided")
+			assert.ErrorContains(t, err, "a factory for type string has already been provided")
+
+			_, exists := containerA.providers[reflect.TypeOf(true)]
+			assert.Assert(t, exists)
+		})
+
+		t.Run("Merge with a single conflict", func(t *testing.T) {
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() string { return "Hello" })
+			containerB.Provide(func() string { return "World" })
+
+			err := containerA.MergeAll(containerB)
+			assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "string"})
+		})
+	})
+
+	t.Run("Merge registrations", func(t *testing.T) {
+		t.Run("Decorators are merged", func(t *testing.T) {
+			module := New()
+			module.Provide(func() string { return "base" })
+			module.Decorate(func(inner string) string { return inner + "+low" })
+			module.Decorate(func(inner string) string { return inner + "+high" }, Priority(1))
+
+			c := New()
+			assert.NilError(t, c.Merge(module))
+
+			got, err := Resolve[string](c)
+			assert.NilError(t, err)
+			assert.Equal(t, got, "base+high+low")
+		})
+
+		t.Run("Cleanups are merged", func(t *testing.T) {
+			closed := false
+			module := New()
+			module.Provide(func() *net.IPNet { return &net.IPNet{} }, WithCleanup(func(n *net.IPNet) { closed = true }))
+
+			c := New()
+			assert.NilError(t, c.MergeAll(module))
+			assert.NilError(t, c.Run(func(n *net.IPNet) {}))
+			assert.Assert(t, closed)
+		})
+
+		t.Run("Keyed factories, bindings and converters are merged", func(t *testing.T) {
+			module := New()
+			module.ProvideKeyed("primary", func() int { return 1 })
+			module.Provide(func() *strings.Builder { return &strings.Builder{} }, As(new(fmt.Stringer)))
+			module.ProvideConverter(func(n map[string]int) float64 { return float64(n["primary"]) })
+
+			c := New()
+			assert.NilError(t, c.Merge(module))
+
+			err := c.Run(func(n map[string]int, s fmt.Stringer, f float64) {
+				assert.Equal(t, n["primary"], 1)
+				assert.Equal(t, f, 1.0)
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Conflicting keys are reported", func(t *testing.T) {
+			module := New()
+			module.ProvideKeyed("primary", func() int { return 1 })
+
+			c := New()
+			c.ProvideKeyed("primary", func() int { return 2 })
+
+			err := c.Merge(module)
+			assert.ErrorIs(t, err, errs.KeyAlreadyProvidedError{Key: "primary", TypeName: "int"})
+		})
+	})
+
+	t.Run("MergeWith", func(t *testing.T) {
+		newSource := func(calls *int) *Container {
+			source := New()
+			source.Provide(func() *strings.Builder {
+				*calls++
+				return &strings.Builder{}
+			})
+			return source
+		}
+
+		t.Run("Drop builds the merged types again", func(t *testing.T) {
+			calls := 0
+			source := newSource(&calls)
+			built, _ := Resolve[*strings.Builder](source)
+
+			c := New()
+			assert.NilError(t, c.MergeWith(source, InstancesDrop))
+
+			merged, err := Resolve[*strings.Builder](c)
+			assert.NilError(t, err)
+			assert.Assert(t, merged != built)
+			assert.Equal(t, calls, 2)
+		})
+
+		t.Run("Reuse keeps the built values", func(t *testing.T) {
+			calls := 0
+			source := newSource(&calls)
+			built, _ := Resolve[*strings.Builder](source)
+
+			c := New()
+			assert.NilError(t, c.MergeWith(source, InstancesReuse))
+
+			merged, err := Resolve[*strings.Builder](c)
+			assert.NilError(t, err)
+			assert.Assert(t, merged == built)
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Reuse writes through the instance store", func(t *testing.T) {
+			calls := 0
+			source := newSource(&calls)
+			built, _ := Resolve[*strings.Builder](source)
+
+			c := New()
+			store := &fakeStore{values: map[reflect.Type]reflect.Value{}}
+			c.SetInstanceStore(store)
+			assert.NilError(t, c.MergeWith(source, InstancesReuse))
+
+			assert.DeepEqual(t, typeNames(store.sets), []string{"*strings.Builder"})
+			assert.Equal(t, len(c.instances), 0)
+
+			merged, err := Resolve[*strings.Builder](c)
+			assert.NilError(t, err)
+			assert.Assert(t, merged == built)
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Error rejects built values", func(t *testing.T) {
+			calls := 0
+			source := newSource(&calls)
+			Resolve[*strings.Builder](source)
+
+			c := New()
+			err := c.MergeWith(source, InstancesError)
+			assert.ErrorIs(t, err, errs.CachedInstanceError{TypeName: "*strings.Builder"})
+
+			_, exists := c.providers[reflect.TypeOf(&strings.Builder{})]
+			assert.Assert(t, !exists)
+		})
+
+		t.Run("Error accepts unbuilt types", func(t *testing.T) {
+			calls := 0
+			source := newSource(&calls)
+
+			c := New()
+			assert.NilError(t, c.MergeWith(source, InstancesError))
+
+			_, err := Resolve[*strings.Builder](c)
+			assert.NilError(t, err)
+			assert.Equal(t, calls, 1)
+		})
+	})
+
+	t.Run("MergeNamespaced", func(t *testing.T) {
+		t.Run("Same types resolve through their namespace", func(t *testing.T) {
+			c := New()
+			containerA := New()
+			containerB := New()
+
+			containerA.Provide(func() *net.IPNet { return &net.IPNet{IP: net.IPv4(10, 0, 0, 1)} })
+			containerB.Provide(func() *net.IPNet { return &net.IPNet{IP: net.IPv4(10, 0, 0, 2)} })
+
+			assert.NilError(t, c.MergeNamespaced(containerA, "a."))
+			assert.NilError(t, c.MergeNamespaced(containerB, "b."))
+
+			err := c.Run(func(networks map[string]*net.IPNet) {
+				assert.Equal(t, len(networks), 2)
+				assert.Equal(t, networks["a."].IP.String(), "10.0.0.1")
+				assert.Equal(t, networks["b."].IP.String(), "10.0.0.2")
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Keyed factories are prefixed", func(t *testing.T) {
+			c := New()
+			other := New()
+
+			other.ProvideKeyed("primary", func() string { return "db-1:5432" })
+			other.ProvideKeyed("replica", func() string { return "db-2:5432" })
+			c.ProvideKeyed("primary", func() string { return "db-0:5432" })
+
+			assert.NilError(t, c.MergeNamespaced(other, "other."))
+
+			endpoints, err := Resolve[map[string]string](c)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, endpoints, map[string]string{
+				"primary":       "db-0:5432",
+				"other.primary": "db-1:5432",
+				"other.replica": "db-2:5432",
+			})
+		})
+
+		t.Run("Every returned type is namespaced", func(t *testing.T) {
+			c := New()
+			other := New()
+
+			other.Provide(func() (string, int, error) { return "localhost", 8080, nil }, MultiReturn())
+
+			assert.NilError(t, c.MergeNamespaced(other, "other"))
+
+			err := c.Run(func(hosts map[string]string, ports map[string]int) {
+				assert.Equal(t, hosts["other"], "localhost")
+				assert.Equal(t, ports["other"], 8080)
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Dependencies resolve from the current container", func(t *testing.T) {
+			c := New()
+			other := New()
+
+			c.Provide(func() int { return 8080 })
+			other.Provide(func(port int) string { return fmt.Sprintf("localhost:%d", port) })
+
+			assert.NilError(t, c.MergeNamespaced(other, "other"))
+
+			addresses, err := Resolve[map[string]string](c)
+			assert.NilError(t, err)
+			assert.Equal(t, addresses["other"], "localhost:8080")
+		})
+
+		t.Run("Merging the same namespace twice conflicts", func(t *testing.T) {
+			c := New()
+			other := New()
+
+			other.Provide(func() string { return "Hello" })
+
+			assert.NilError(t, c.MergeNamespaced(other, "other"))
+
+			err := c.MergeNamespaced(other, "other")
+			assert.ErrorIs(t, err, errs.KeyAlreadyProvidedError{Key: "other", TypeName: "string"})
+		})
+	})
+}
+
+// domainError is a custom error returned by factories in tests.
+type domainError struct {
+	Code int
+}
+
+func (e *domainError) Error() string {
+	return fmt.Sprintf("domain error %d", e.Code)
 }
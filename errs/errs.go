@@ -1,12 +1,20 @@
 package errs
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
+// ErrSkip is returned by a factory that does not apply at resolution time.
+// The container then treats its type as unprovided and falls through to the alternatives,
+// such as bindings and converters, or fails with a DependencyResolutionError if there are none.
+var ErrSkip = errors.New("factory skipped its type")
+
 // NotAFunctionError indicates that the provided object is not a function.
 type NotAFunctionError struct{}
 
@@ -35,6 +43,25 @@ func (e UnexpectedReturnTypeError) Error() string {
 	return fmt.Sprintf("unexpected return type: %s", e.TypeName)
 }
 
+// TooManyParametersError indicates that a function given to Run takes more parameters than the container allows.
+type TooManyParametersError struct {
+	Count int
+	Max   int
+}
+
+// Error returns a string representation of the TooManyParametersError.
+func (e TooManyParametersError) Error() string {
+	return fmt.Sprintf("function takes %d parameters, at most %d are allowed", e.Count, e.Max)
+}
+
+// MissingErrorReturnError indicates that a function given to Run does not return an error while the container requires it.
+type MissingErrorReturnError struct{}
+
+// Error returns a string representation of the MissingErrorReturnError.
+func (e MissingErrorReturnError) Error() string {
+	return "function must return an error"
+}
+
 // FactoryAlreadyProvidedError indicates that a factory for the given type has already been registered.
 type FactoryAlreadyProvidedError struct {
 	TypeName string
@@ -45,6 +72,155 @@ func (e FactoryAlreadyProvidedError) Error() string {
 	return fmt.Sprintf("a factory for type %s has already been provided", e.TypeName)
 }
 
+// KeyAlreadyProvidedError indicates that a keyed factory for the given type and key has already been registered.
+type KeyAlreadyProvidedError struct {
+	Key      string
+	TypeName string
+}
+
+// Error returns a string representation of the KeyAlreadyProvidedError.
+func (e KeyAlreadyProvidedError) Error() string {
+	return fmt.Sprintf("a factory for type %s has already been provided with key %q", e.TypeName, e.Key)
+}
+
+// CachedInstanceError indicates that a container could not be merged because it had already built a merged type.
+type CachedInstanceError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the CachedInstanceError.
+func (e CachedInstanceError) Error() string {
+	return fmt.Sprintf("cannot merge type %s: it was already built by the merged container", e.TypeName)
+}
+
+// ConflictingRegistrationError indicates that a registration is both named and part of a group.
+type ConflictingRegistrationError struct {
+	Name  string
+	Group string
+}
+
+// Error returns a string representation of the ConflictingRegistrationError.
+func (e ConflictingRegistrationError) Error() string {
+	return fmt.Sprintf("registration cannot be both named %q and part of group %q", e.Name, e.Group)
+}
+
+// FrozenError indicates that a registration was attempted on a container that has been frozen.
+type FrozenError struct{}
+
+// Error returns a string representation of the FrozenError.
+func (e FrozenError) Error() string {
+	return "container is frozen and accepts no more registrations"
+}
+
+// RunnerRunningError indicates that a Runner was started while it was already running.
+type RunnerRunningError struct{}
+
+// Error returns a string representation of the RunnerRunningError.
+func (e RunnerRunningError) Error() string {
+	return "runner is already running"
+}
+
+// ContainerStoppedError indicates that a value was requested from a container after Close stopped it.
+// Building the value then would leave it without anything to run its stop hooks.
+type ContainerStoppedError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the ContainerStoppedError.
+func (e ContainerStoppedError) Error() string {
+	return fmt.Sprintf("cannot resolve type %s: container is stopped", e.TypeName)
+}
+
+// InvalidDecoratorError indicates that a decorator does not take the type it returns.
+type InvalidDecoratorError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the InvalidDecoratorError.
+func (e InvalidDecoratorError) Error() string {
+	return fmt.Sprintf("decorator for type %s must take a parameter of the same type", e.TypeName)
+}
+
+// InvalidConverterError indicates that a converter does not take exactly one parameter of a different type.
+type InvalidConverterError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the InvalidConverterError.
+func (e InvalidConverterError) Error() string {
+	return fmt.Sprintf("converter to type %s must take exactly one parameter of another type", e.TypeName)
+}
+
+// InvalidCleanupError indicates that a cleanup does not take a value returned by its factory,
+// or returns something other than an error.
+type InvalidCleanupError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the InvalidCleanupError.
+func (e InvalidCleanupError) Error() string {
+	return fmt.Sprintf("cleanup for type %s must take a value returned by its factory and return at most an error", e.TypeName)
+}
+
+// NilValueError indicates that a nil value was supplied, whose type cannot be determined.
+type NilValueError struct{}
+
+// Error returns a string representation of the NilValueError.
+func (e NilValueError) Error() string {
+	return "supplied value must not be nil"
+}
+
+// LateProvideError indicates that a type was registered after it, or a value depending on it, had already been resolved.
+type LateProvideError struct {
+	TypeName     string
+	ResolvedName string
+}
+
+// Error returns a string representation of the LateProvideError.
+func (e LateProvideError) Error() string {
+	return fmt.Sprintf("late registration of type %s: %s was already resolved", e.TypeName, e.ResolvedName)
+}
+
+// MismatchedValueError indicates that a value given for a type cannot be assigned to it.
+type MismatchedValueError struct {
+	TypeName  string
+	ValueType string
+}
+
+// Error returns a string representation of the MismatchedValueError.
+func (e MismatchedValueError) Error() string {
+	return fmt.Sprintf("value of type %s cannot be used as %s", e.ValueType, e.TypeName)
+}
+
+// NotAPointerError indicates that a target to fill is not a non-nil pointer.
+type NotAPointerError struct{}
+
+// Error returns a string representation of the NotAPointerError.
+func (e NotAPointerError) Error() string {
+	return "target must be a non-nil pointer"
+}
+
+// NotAStructError indicates that a type expected to be a struct, or a pointer to one, is not one.
+type NotAStructError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the NotAStructError.
+func (e NotAStructError) Error() string {
+	return fmt.Sprintf("type %s is not a struct or a pointer to one", e.TypeName)
+}
+
+// ConstructTimeoutError indicates that a factory took longer than its construction timeout to build its type.
+type ConstructTimeoutError struct {
+	TypeName string
+	Timeout  time.Duration
+}
+
+// Error returns a string representation of the ConstructTimeoutError.
+func (e ConstructTimeoutError) Error() string {
+	return fmt.Sprintf("construction of type %s timed out after %s", e.TypeName, e.Timeout)
+}
+
 // DependencyResolutionError indicates that a dependency could not be resolved.
 type DependencyResolutionError struct {
 	TypeName string
@@ -55,6 +231,57 @@ func (e DependencyResolutionError) Error() string {
 	return fmt.Sprintf("failed to resolve dependency for type %s", e.TypeName)
 }
 
+// ProvidedAsError indicates that a type could not be resolved while a factory provides a close type instead,
+// such as an interface the type implements, or the type with a different pointer-ness.
+// Containers in strict mode report it in place of a DependencyResolutionError.
+type ProvidedAsError struct {
+	TypeName     string
+	ProvidedName string
+}
+
+// Error returns a string representation of the ProvidedAsError.
+func (e ProvidedAsError) Error() string {
+	return fmt.Sprintf("failed to resolve dependency for type %s: only %s is provided", e.TypeName, e.ProvidedName)
+}
+
+// AmbiguousDependencyError indicates that several providers can satisfy the requested type.
+type AmbiguousDependencyError struct {
+	TypeName   string
+	Candidates []string
+}
+
+// Error returns a string representation of the AmbiguousDependencyError.
+func (e AmbiguousDependencyError) Error() string {
+	return fmt.Sprintf("ambiguous dependency for type %s: candidates are %s", e.TypeName, strings.Join(e.Candidates, ", "))
+}
+
+// Is reports whether the target is an AmbiguousDependencyError with the same type and candidates.
+func (e AmbiguousDependencyError) Is(target error) bool {
+	other, ok := target.(AmbiguousDependencyError)
+	return ok && other.TypeName == e.TypeName && slices.Equal(other.Candidates, e.Candidates)
+}
+
+// NotAnInterfaceError indicates that a type expected to be an interface is not one.
+type NotAnInterfaceError struct {
+	TypeName string
+}
+
+// Error returns a string representation of the NotAnInterfaceError.
+func (e NotAnInterfaceError) Error() string {
+	return fmt.Sprintf("type %s is not an interface", e.TypeName)
+}
+
+// InterfaceNotImplementedError indicates that a type does not implement the interface it is bound to.
+type InterfaceNotImplementedError struct {
+	TypeName      string
+	InterfaceName string
+}
+
+// Error returns a string representation of the InterfaceNotImplementedError.
+func (e InterfaceNotImplementedError) Error() string {
+	return fmt.Sprintf("type %s does not implement interface %s", e.TypeName, e.InterfaceName)
+}
+
 // CyclicDependencyError indicates that a cyclic dependency was detected.
 type CyclicDependencyError struct {
 	TypeName string
@@ -65,9 +292,53 @@ func (e CyclicDependencyError) Error() string {
 	return fmt.Sprintf("cyclic dependency detected for type %s", e.TypeName)
 }
 
+// ParameterError indicates that a parameter of a function run by the container could not be resolved.
+// It keeps the position and type of the parameter along with the underlying error, which it unwraps to.
+type ParameterError struct {
+	Index    int
+	TypeName string
+	Err      error
+}
+
+// Error returns a string representation of the ParameterError.
+func (e ParameterError) Error() string {
+	return fmt.Sprintf("parameter %d of type %s: %s", e.Index, e.TypeName, e.Err)
+}
+
+// Unwrap returns the error the parameter failed to resolve with.
+func (e ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError indicates that a panic was recovered and converted into an error.
+type PanicError struct {
+	Source string
+	Value  interface{}
+}
+
+// Error returns a string representation of the PanicError.
+func (e PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Source, e.Value)
+}
+
+// ResultStrategy controls what ErrorSet.Result returns when the set holds several errors.
+type ResultStrategy int
+
+const (
+	// ResultAll returns the only error of the set, or the set itself when there are several. It is the default.
+	ResultAll ResultStrategy = iota
+	// ResultFirst returns the first error added to the set.
+	ResultFirst
+	// ResultLast returns the last error added to the set.
+	ResultLast
+)
+
 // ErrorSet is a collection of errors.
 // It can be used to accumulate errors and retrieve them as a single error or a list.
+// Strategy controls what Result returns, and defaults to ResultAll.
 type ErrorSet struct {
+	Strategy ResultStrategy
+
 	mu     sync.Mutex
 	errors []error
 }
@@ -79,10 +350,12 @@ func (es *ErrorSet) Add(err error) {
 	es.errors = append(es.errors, err)
 }
 
-// Errors returns the list of errors in the error set.
+// Errors returns a copy of the errors in the error set, in the order they were added.
 func (es *ErrorSet) Errors() []error {
-	slices.Reverse(es.errors)
-	return es.errors
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	return slices.Clone(es.errors)
 }
 
 // Error implements the error interface.
@@ -97,6 +370,7 @@ func (es *ErrorSet) Error() string {
 
 // Result returns a single error if there's only one error in the set,
 // the ErrorSet itself if there's more than one error, or nil if there are no errors.
+// With the ResultFirst or ResultLast strategy, only the first or last error added is returned instead of the set.
 // Example:
 //
 //	errSet := &ErrorSet{}
@@ -105,6 +379,20 @@ func (es *ErrorSet) Error() string {
 //	err := errSet.Result()
 //	fmt.Println(err) // Outputs: "First error; Second error"
 func (es *ErrorSet) Result() error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if len(es.errors) == 0 {
+		return nil
+	}
+
+	switch es.Strategy {
+	case ResultFirst:
+		return es.errors[0]
+	case ResultLast:
+		return es.errors[len(es.errors)-1]
+	}
+
 	if len(es.errors) == 1 {
 		return es.errors[0]
 	}
@@ -116,8 +404,82 @@ func (es *ErrorSet) Result() error {
 	return nil
 }
 
+// Join returns the errors in the set combined with errors.Join.
+// The result works with errors.Is and errors.As, or is nil if the set is empty.
+func (es *ErrorSet) Join() error {
+	return errors.Join(es.Errors()...)
+}
+
+// As finds the first error in the set that matches target, as errors.As does for a single error.
+// It lets errors.As retrieve a custom error type from an ErrorSet.
+func (es *ErrorSet) As(target any) bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	for _, err := range es.errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsEmpty checks if the ErrorSet has no errors.
 // It returns true if the ErrorSet is empty, otherwise false.
 func (me *ErrorSet) IsEmpty() bool {
 	return len(me.errors) == 0
 }
+
+// Combine flattens the given errors into a single ErrorSet, in order: the errors of an ErrorSet, nested or not,
+// take its place, and nil errors are skipped. It aggregates the errors of independent runs into one structure.
+// Returns nil if there is no error left.
+//
+// Example:
+//
+//	err := errs.Combine(c.Run(migrate), c.Run(seed))
+func Combine(errs ...error) error {
+	return combine(errs, false)
+}
+
+// CombineUnique flattens the given errors like Combine, keeping only the first of identical errors,
+// that is of errors equal with ==, such as the same sentinel or two equal error structs.
+//
+// Example:
+//
+//	err := errs.CombineUnique(results...)
+func CombineUnique(errs ...error) error {
+	return combine(errs, true)
+}
+
+// combine flattens the given errors into a new ErrorSet, dropping identical errors if unique is set.
+func combine(errs []error, unique bool) error {
+	combined := &ErrorSet{}
+	flatten(combined, errs, unique)
+
+	if combined.IsEmpty() {
+		return nil
+	}
+
+	return combined
+}
+
+// flatten adds the given errors to the set, unfolding the ErrorSets among them.
+func flatten(combined *ErrorSet, errs []error, unique bool) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if nested, ok := err.(*ErrorSet); ok {
+			flatten(combined, nested.Errors(), unique)
+			continue
+		}
+
+		if unique && reflect.TypeOf(err).Comparable() && slices.Contains(combined.errors, err) {
+			continue
+		}
+
+		combined.Add(err)
+	}
+}
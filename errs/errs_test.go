@@ -0,0 +1,140 @@
+package errs
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestErrorSet(t *testing.T) {
+	t.Run("Join", func(t *testing.T) {
+		t.Run("should return nil for an empty set", func(t *testing.T) {
+			es := &ErrorSet{}
+			assert.NilError(t, es.Join())
+		})
+
+		t.Run("should be inspectable with errors.Is", func(t *testing.T) {
+			first := errors.New("first error")
+			second := DependencyResolutionError{TypeName: "int"}
+
+			es := &ErrorSet{}
+			es.Add(first)
+			es.Add(second)
+
+			joined := es.Join()
+			assert.Assert(t, errors.Is(joined, first))
+			assert.Assert(t, errors.Is(joined, second))
+			assert.Assert(t, !errors.Is(joined, CyclicDependencyError{TypeName: "int"}))
+		})
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Run("should keep the order errors were added in", func(t *testing.T) {
+			es := &ErrorSet{}
+			es.Add(errors.New("first error"))
+			es.Add(errors.New("second error"))
+
+			assert.Equal(t, len(es.Errors()), 2)
+			assert.Equal(t, es.Errors()[0].Error(), "first error")
+			assert.Equal(t, es.Error(), "first error; second error")
+			// Reading the errors again must not change their order.
+			assert.Equal(t, es.Error(), "first error; second error")
+		})
+	})
+
+	t.Run("As", func(t *testing.T) {
+		t.Run("should find a contained error", func(t *testing.T) {
+			es := &ErrorSet{}
+			es.Add(errors.New("first error"))
+			es.Add(DependencyResolutionError{TypeName: "int"})
+
+			var target DependencyResolutionError
+			assert.Assert(t, errors.As(es, &target))
+			assert.Equal(t, target.TypeName, "int")
+		})
+
+		t.Run("should report a missing error", func(t *testing.T) {
+			es := &ErrorSet{}
+			es.Add(errors.New("first error"))
+
+			var target CyclicDependencyError
+			assert.Assert(t, !errors.As(es, &target))
+		})
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		first := errors.New("first error")
+		second := errors.New("second error")
+		third := errors.New("third error")
+
+		fill := func(strategy ResultStrategy) *ErrorSet {
+			es := &ErrorSet{Strategy: strategy}
+			es.Add(first)
+			es.Add(second)
+			es.Add(third)
+			return es
+		}
+
+		t.Run("should return the set by default", func(t *testing.T) {
+			es := fill(ResultAll)
+			assert.Equal(t, es.Result(), error(es))
+		})
+
+		t.Run("should return the first error", func(t *testing.T) {
+			assert.Equal(t, fill(ResultFirst).Result(), first)
+		})
+
+		t.Run("should return the last error", func(t *testing.T) {
+			assert.Equal(t, fill(ResultLast).Result(), third)
+		})
+
+		t.Run("should return nil for an empty set", func(t *testing.T) {
+			for _, strategy := range []ResultStrategy{ResultAll, ResultFirst, ResultLast} {
+				es := &ErrorSet{Strategy: strategy}
+				assert.NilError(t, es.Result())
+			}
+		})
+	})
+}
+
+func TestCombine(t *testing.T) {
+	first := errors.New("first error")
+	second := DependencyResolutionError{TypeName: "int"}
+	third := errors.New("third error")
+
+	nested := &ErrorSet{}
+	nested.Add(second)
+	nested.Add(first)
+
+	set := &ErrorSet{}
+	set.Add(nested)
+	set.Add(third)
+
+	t.Run("should flatten sets and plain errors in order", func(t *testing.T) {
+		combined, ok := Combine(first, nil, set, second).(*ErrorSet)
+
+		assert.Assert(t, ok)
+		assert.Assert(t, slices.Equal(combined.Errors(), []error{first, second, first, third, second}))
+	})
+
+	t.Run("should drop identical errors", func(t *testing.T) {
+		combined, ok := CombineUnique(first, nil, set, DependencyResolutionError{TypeName: "int"}).(*ErrorSet)
+
+		assert.Assert(t, ok)
+		assert.Assert(t, slices.Equal(combined.Errors(), []error{first, second, third}))
+	})
+
+	t.Run("should keep a single error in a set", func(t *testing.T) {
+		combined, ok := Combine(first).(*ErrorSet)
+
+		assert.Assert(t, ok)
+		assert.Assert(t, slices.Equal(combined.Errors(), []error{first}))
+	})
+
+	t.Run("should return nil without errors", func(t *testing.T) {
+		assert.NilError(t, Combine())
+		assert.NilError(t, Combine(nil, &ErrorSet{}))
+	})
+}
@@ -0,0 +1,95 @@
+package zeus
+
+import (
+	"reflect"
+	"time"
+)
+
+// eventBufferSize is the number of events kept for a slow reader of Events before new ones are dropped.
+const eventBufferSize = 256
+
+// Phase identifies the step of the container lifecycle an Event refers to.
+type Phase int
+
+const (
+	// PhaseConstructing is emitted right before a type is built.
+	PhaseConstructing Phase = iota
+	// PhaseConstructed is emitted after a type is built, with Err set if its construction failed.
+	PhaseConstructed
+	// PhaseStarting is emitted before the start hooks run.
+	PhaseStarting
+	// PhaseStarted is emitted after the start hooks ran, with Err set if one of them failed.
+	PhaseStarted
+	// PhaseStopping is emitted before the stop hooks run.
+	PhaseStopping
+	// PhaseStopped is emitted after the stop hooks ran, with Err set if one of them failed.
+	PhaseStopped
+)
+
+// String returns a readable name for the phase.
+func (p Phase) String() string {
+	switch p {
+	case PhaseConstructing:
+		return "constructing"
+	case PhaseConstructed:
+		return "constructed"
+	case PhaseStarting:
+		return "starting"
+	case PhaseStarted:
+		return "started"
+	case PhaseStopping:
+		return "stopping"
+	case PhaseStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a step of the container lifecycle.
+// Type is the type being built for construction phases and nil for hook phases.
+type Event struct {
+	Type  reflect.Type
+	Phase Phase
+	Err   error
+	Time  time.Time
+}
+
+// Events returns a channel receiving an Event for every construction and hook phase of the container.
+// The channel is buffered and written without blocking, so events are dropped while the buffer is full
+// rather than stalling the resolution. Every call returns the same channel.
+//
+// Example:
+//
+//	events := c.Events()
+//	go func() {
+//	    for e := range events {
+//	        log.Println(e.Phase, e.Type, e.Err)
+//	    }
+//	}()
+func (c *Container) Events() <-chan Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.events == nil {
+		c.events = make(chan Event, eventBufferSize)
+	}
+
+	return c.events
+}
+
+// emit publishes an event to the Events channel, if anyone asked for it.
+func (c *Container) emit(t reflect.Type, phase Phase, err error) {
+	c.mu.RLock()
+	events := c.events
+	c.mu.RUnlock()
+
+	if events == nil {
+		return
+	}
+
+	select {
+	case events <- Event{Type: t, Phase: phase, Err: err, Time: time.Now()}:
+	default:
+	}
+}
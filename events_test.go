@@ -0,0 +1,97 @@
+package zeus
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	type step struct {
+		Type  string
+		Phase Phase
+		Err   error
+	}
+
+	drain := func(events <-chan Event) []step {
+		steps := []step{}
+
+		for {
+			select {
+			case e := <-events:
+				assert.Assert(t, !e.Time.IsZero())
+				steps = append(steps, step{Type: fmt.Sprint(e.Type), Phase: e.Phase, Err: e.Err})
+			default:
+				return steps
+			}
+		}
+	}
+
+	t.Run("Run sequence", func(t *testing.T) {
+		c := New()
+		events := c.Events()
+
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int) string { return "Hello" })
+
+		err := c.Run(func(s string) {})
+		assert.NilError(t, err)
+
+		expected := []step{
+			{Type: "string", Phase: PhaseConstructing},
+			{Type: "int", Phase: PhaseConstructing},
+			{Type: "int", Phase: PhaseConstructed},
+			{Type: "string", Phase: PhaseConstructed},
+			{Type: "<nil>", Phase: PhaseStarting},
+			{Type: "<nil>", Phase: PhaseStarted},
+			{Type: "<nil>", Phase: PhaseStopping},
+			{Type: "<nil>", Phase: PhaseStopped},
+		}
+
+		assert.DeepEqual(t, drain(events), expected)
+
+		c.Run(func(s string) {})
+		assert.DeepEqual(t, drain(events), expected[4:])
+	})
+
+	t.Run("Construction error", func(t *testing.T) {
+		c := New()
+		events := c.Events()
+		failure := errors.New("some error")
+
+		c.Provide(func() (int, error) { return 0, failure })
+		c.Run(func(i int) {})
+
+		steps := drain(events)
+		assert.Equal(t, len(steps), 2)
+		assert.Equal(t, steps[1].Phase, PhaseConstructed)
+		assert.Equal(t, steps[1].Err, failure)
+	})
+
+	t.Run("Full buffer drops events", func(t *testing.T) {
+		c := New()
+		events := c.Events()
+		c.Provide(func() int { return 42 })
+
+		for i := 0; i < eventBufferSize; i++ {
+			c.Run(func() {})
+		}
+
+		assert.Equal(t, len(events), eventBufferSize)
+	})
+
+	t.Run("Same channel", func(t *testing.T) {
+		c := New()
+		assert.Equal(t, c.Events(), c.Events())
+	})
+
+	t.Run("Phase names", func(t *testing.T) {
+		assert.Equal(t, PhaseConstructing.String(), "constructing")
+		assert.Equal(t, PhaseStopped.String(), "stopped")
+		assert.Equal(t, Phase(-1).String(), "unknown")
+	})
+}
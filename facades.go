@@ -1,12 +1,22 @@
 package zeus
 
 import (
+	"github.com/otoru/zeus/errs"
 	"github.com/otoru/zeus/hooks"
 )
 
+// ErrSkip is a facade for errs.ErrSkip
+var ErrSkip = errs.ErrSkip
+
 // Hooks is a facade for hooks.Hooks
 type Hooks hooks.Hooks
 
+// RetryHooks is a facade for hooks.RetryHooks
+type RetryHooks hooks.RetryHooks
+
+// ContextHooks is a facade for hooks.ContextHooks
+type ContextHooks hooks.ContextHooks
+
 // ErrorSet is a facade for errs.ErrorSet
 type ErrorSet interface {
 	IsEmpty() bool
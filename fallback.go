@@ -0,0 +1,73 @@
+package zeus
+
+import (
+	"reflect"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// FallbackResolver builds values for types the container cannot otherwise provide.
+// It reports whether it handled the type: a false result leaves the type unresolved, as if there were no fallback.
+// The given resolve function builds other types from the container within the same construction,
+// and must be used instead of Resolve, so that requesting the type being built fails as a cycle instead of waiting on itself.
+type FallbackResolver func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error)
+
+// SetFallbackResolver sets a last-resort resolver, asked for a type when nothing else can build it,
+// right before the resolution would fail with an errs.DependencyResolutionError.
+// A value it returns is used and cached like one built by a factory, and an invalid value stands for the zero value of the type.
+// A type requested again through the resolve function while its fallback is running fails with an errs.CyclicDependencyError,
+// while other resolutions of the type wait for the fallback like they wait for a factory.
+// Returns an errs.MismatchedValueError at resolution time if the value cannot be assigned to the type.
+// Validate, and the other static checks, ask the fallback for the types nothing else provides and discard its value,
+// so the resolver may be called more than once for a type and should not have side effects.
+//
+// Example:
+//
+//	c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+//	    plugin, ok := plugins.Lookup(t)
+//	    return reflect.ValueOf(plugin), ok, nil
+//	})
+func (c *Container) SetFallbackResolver(resolver FallbackResolver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resolver = resolver
+}
+
+// resolveFallback asks the fallback resolver for the given type, and reports whether it handled it.
+// The type is on the stack of the construction, which the resolver resolves its own dependencies with to detect recursion.
+func (c *Container) resolveFallback(t reflect.Type, stack *typeStack) (reflect.Value, bool, error) {
+	c.mu.RLock()
+	resolver := c.resolver
+	c.mu.RUnlock()
+
+	if resolver == nil {
+		return reflect.Value{}, false, nil
+	}
+
+	resolve := func(dependency reflect.Type) (reflect.Value, error) {
+		return c.resolve(dependency, stack.clone())
+	}
+
+	value, ok, err := resolver(t, resolve)
+
+	if err != nil || !ok {
+		return reflect.Value{}, false, err
+	}
+
+	if !value.IsValid() {
+		return reflect.Zero(t), true, nil
+	}
+
+	if !value.Type().AssignableTo(t) {
+		return reflect.Value{}, false, errs.MismatchedValueError{TypeName: t.String(), ValueType: value.Type().String()}
+	}
+
+	if value.Type() != t {
+		converted := reflect.New(t).Elem()
+		converted.Set(value)
+		value = converted
+	}
+
+	return value, true, nil
+}
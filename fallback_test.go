@@ -0,0 +1,179 @@
+package zeus
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestSetFallbackResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Unprovided types are supplied by the fallback and cached", func(t *testing.T) {
+		c := New()
+		calls := 0
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			if t != reflect.TypeOf(&net.IPNet{}) {
+				return reflect.Value{}, false, nil
+			}
+
+			calls++
+			return reflect.ValueOf(&net.IPNet{IP: net.IPv4(10, 0, 0, 1)}), true, nil
+		})
+		c.Provide(func(network *net.IPNet) string { return network.IP.String() })
+
+		err := c.Run(func(address string, network *net.IPNet) {
+			assert.Equal(t, address, "10.0.0.1")
+			assert.Equal(t, network.IP.String(), "10.0.0.1")
+		})
+		assert.NilError(t, err)
+
+		err = c.Run(func(network *net.IPNet) {})
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Providers take precedence over the fallback", func(t *testing.T) {
+		c := New()
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			return reflect.ValueOf(0), true, nil
+		})
+		c.Provide(func() int { return 8080 })
+
+		port, err := Resolve[int](c)
+		assert.NilError(t, err)
+		assert.Equal(t, port, 8080)
+	})
+
+	t.Run("Unhandled types still fail", func(t *testing.T) {
+		c := New()
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			return reflect.Value{}, false, nil
+		})
+
+		_, err := Resolve[int](c)
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "int"})
+	})
+
+	t.Run("Validate accepts types supplied by the fallback", func(t *testing.T) {
+		c := New()
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			if t != reflect.TypeOf(&net.IPNet{}) {
+				return reflect.Value{}, false, nil
+			}
+
+			return reflect.ValueOf(&net.IPNet{}), true, nil
+		})
+		c.Provide(func(network *net.IPNet) string { return network.String() })
+
+		assert.NilError(t, c.Validate())
+
+		c.Provide(func(f float64) int { return int(f) })
+
+		issues := c.ValidationIssues()
+		assert.Equal(t, len(issues), 1)
+		assert.Equal(t, issues[0].Kind, IssueMissing)
+		assert.ErrorIs(t, issues[0].Err, errs.DependencyResolutionError{TypeName: "float64"})
+	})
+
+	t.Run("Errors of the fallback are returned", func(t *testing.T) {
+		c := New()
+		failure := errors.New("plugin not loaded")
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			return reflect.Value{}, false, failure
+		})
+
+		_, err := Resolve[int](c)
+		assert.ErrorIs(t, err, failure)
+	})
+
+	t.Run("Values are converted to interfaces they implement", func(t *testing.T) {
+		c := New()
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			return reflect.ValueOf(&net.IPNet{IP: net.IPv4(10, 0, 0, 1), Mask: net.CIDRMask(8, 32)}), true, nil
+		})
+
+		stringer, err := Resolve[fmt.Stringer](c)
+		assert.NilError(t, err)
+		assert.Equal(t, stringer.String(), "10.0.0.1/8")
+	})
+
+	t.Run("Mismatched values fail", func(t *testing.T) {
+		c := New()
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			return reflect.ValueOf("8080"), true, nil
+		})
+
+		_, err := Resolve[int](c)
+		assert.ErrorIs(t, err, errs.MismatchedValueError{TypeName: "int", ValueType: "string"})
+	})
+
+	t.Run("Recursive requests fail instead of recursing", func(t *testing.T) {
+		c := New()
+		var inner error
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			_, inner = resolve(t)
+			return reflect.ValueOf(8080), true, nil
+		})
+
+		port, err := Resolve[int](c)
+		assert.NilError(t, err)
+		assert.Equal(t, port, 8080)
+		assert.ErrorIs(t, inner, errs.CyclicDependencyError{TypeName: "int"})
+	})
+
+	t.Run("Dependencies of the fallback are resolved", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 8 })
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			bits, err := resolve(reflect.TypeOf(0))
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+
+			return reflect.ValueOf(net.CIDRMask(int(bits.Int()), 32)), true, nil
+		})
+
+		mask, err := Resolve[net.IPMask](c)
+		assert.NilError(t, err)
+		assert.Equal(t, mask.String(), "ff000000")
+	})
+
+	t.Run("Concurrent requests wait for the fallback", func(t *testing.T) {
+		c := New()
+		entered, proceed := make(chan struct{}), make(chan struct{})
+		c.SetFallbackResolver(func(t reflect.Type, resolve func(reflect.Type) (reflect.Value, error)) (reflect.Value, bool, error) {
+			close(entered)
+			<-proceed
+			return reflect.ValueOf(8080), true, nil
+		})
+
+		first := make(chan error)
+		go func() {
+			_, err := Resolve[int](c)
+			first <- err
+		}()
+
+		<-entered
+		second := make(chan error)
+		go func() {
+			port, err := Resolve[int](c)
+			if err == nil && port != 8080 {
+				err = fmt.Errorf("unexpected port %d", port)
+			}
+			second <- err
+		}()
+
+		// Give the second resolution time to reach the running fallback.
+		time.Sleep(10 * time.Millisecond)
+		close(proceed)
+		assert.NilError(t, <-first)
+		assert.NilError(t, <-second)
+	})
+}
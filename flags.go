@@ -0,0 +1,34 @@
+package zeus
+
+import "reflect"
+
+var featureFlagsType = reflect.TypeOf((*FeatureFlags)(nil)).Elem()
+
+// FeatureFlags reports which features are enabled, for the factories registered with WhenFlag.
+// The container does not supply it: register a factory for it, which is resolved like any other dependency.
+type FeatureFlags interface {
+	Enabled(flag string) bool
+}
+
+// flagEnabled reports whether the factory of the given type may be used, resolving FeatureFlags if the factory
+// was registered with WhenFlag. Resolving the flags on the stack of the type lets a FeatureFlags factory
+// depending on the gated type be reported as a cycle.
+func (c *Container) flagEnabled(t reflect.Type, stack *typeStack) (bool, error) {
+	c.mu.RLock()
+	flag, gated := c.flags[t]
+	c.mu.RUnlock()
+
+	if !gated {
+		return true, nil
+	}
+
+	value, err := c.resolve(featureFlagsType, stack)
+
+	if err != nil {
+		return false, err
+	}
+
+	flags, _ := value.Interface().(FeatureFlags)
+
+	return flags != nil && flags.Enabled(flag), nil
+}
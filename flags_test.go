@@ -0,0 +1,85 @@
+package zeus
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+// staticFlags is a FeatureFlags enabling the flags set to true.
+type staticFlags map[string]bool
+
+func (f staticFlags) Enabled(flag string) bool {
+	return f[flag]
+}
+
+func TestWhenFlag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Gated factories follow their flag", func(t *testing.T) {
+		flags := staticFlags{}
+		c := New()
+		c.Provide(func() FeatureFlags { return flags })
+		c.Provide(func() *strings.Builder { return &strings.Builder{} }, WhenFlag("beta"))
+
+		_, err := Resolve[*strings.Builder](c)
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: ""})
+
+		flags["beta"] = true
+
+		_, err = Resolve[*strings.Builder](c)
+		assert.NilError(t, err)
+	})
+
+	t.Run("Disabled factories fall through to the alternatives", func(t *testing.T) {
+		flags := staticFlags{}
+		c := New(Prototype())
+		c.Provide(func() FeatureFlags { return flags })
+		c.Provide(func() fmt.Stringer { return &strings.Builder{} }, WhenFlag("beta"))
+		c.Provide(func() *net.IPNet { return &net.IPNet{} }, As(new(fmt.Stringer)))
+
+		stringer, err := Resolve[fmt.Stringer](c)
+		assert.NilError(t, err)
+		_, ok := stringer.(*net.IPNet)
+		assert.Assert(t, ok)
+
+		flags["beta"] = true
+
+		stringer, err = Resolve[fmt.Stringer](c)
+		assert.NilError(t, err)
+		_, ok = stringer.(*strings.Builder)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("Ungated factories ignore the flags", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+
+		n, err := Resolve[int](c)
+		assert.NilError(t, err)
+		assert.Equal(t, n, 42)
+	})
+
+	t.Run("Missing flags fail the gated type", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 }, WhenFlag("beta"))
+
+		_, err := Resolve[int](c)
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "FeatureFlags"})
+		assert.ErrorContains(t, c.Validate(), "FeatureFlags")
+	})
+
+	t.Run("Flags depending on a gated type are a cycle", func(t *testing.T) {
+		c := New()
+		c.Provide(func(n int) FeatureFlags { return staticFlags{"beta": n > 0} })
+		c.Provide(func() int { return 42 }, WhenFlag("beta"))
+
+		_, err := Resolve[int](c)
+		assert.ErrorIs(t, err, errs.CyclicDependencyError{TypeName: "int"})
+		assert.ErrorContains(t, c.Validate(), "cyclic dependency")
+	})
+}
@@ -0,0 +1,54 @@
+package zeus
+
+import "github.com/otoru/zeus/errs"
+
+// Freeze stops the container from accepting registrations, so that its graph cannot change once the application is wired.
+// Every later call registering factories, decorators, converters, aliases or values, or merging another container,
+// fails with an errs.FrozenError. Resolution and runs are not affected.
+//
+// Example:
+//
+//	c.Provide(NewDatabase)
+//	c.Freeze()
+//	err := c.Provide(NewCache) // errs.FrozenError
+func (c *Container) Freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.frozen = true
+}
+
+// Frozen reports whether the container has been frozen with Freeze or Seal.
+func (c *Container) Frozen() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.frozen
+}
+
+// Seal validates the container and freezes it if the graph is valid, in a single step to run once everything is provided.
+// Returns the error of Validate, leaving the container open to registrations, if the graph is invalid.
+//
+// Example:
+//
+//	if err := c.Seal(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Container) Seal() error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	c.Freeze()
+
+	return nil
+}
+
+// checkFrozen returns an errs.FrozenError if the container no longer accepts registrations.
+func (c *Container) checkFrozen() error {
+	if c.Frozen() {
+		return errs.FrozenError{}
+	}
+
+	return nil
+}
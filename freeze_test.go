@@ -0,0 +1,64 @@
+package zeus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Registrations fail once frozen", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Freeze()
+
+		assert.Assert(t, c.Frozen())
+		assert.ErrorIs(t, c.Provide(func() string { return "" }), errs.FrozenError{})
+		assert.ErrorIs(t, c.ProvideKeyed("primary", func() string { return "" }), errs.FrozenError{})
+		assert.ErrorIs(t, c.Supply(3.14), errs.FrozenError{})
+		assert.ErrorIs(t, c.Decorate(func(n int) int { return n + 1 }), errs.FrozenError{})
+		assert.ErrorIs(t, c.Merge(New()), errs.FrozenError{})
+		assert.ErrorIs(t, c.MergeAll(New()), errs.FrozenError{})
+		assert.ErrorIs(t, c.MergeNamespaced(New(), "other"), errs.FrozenError{})
+		assert.ErrorIs(t, c.Before(0, ""), errs.FrozenError{})
+		assert.Equal(t, len(c.predecessors(reflect.TypeOf(""))), 0)
+	})
+
+	t.Run("Resolution still works once frozen", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Freeze()
+
+		err := c.Run(func(n int) { assert.Equal(t, n, 42) })
+		assert.NilError(t, err)
+	})
+}
+
+func TestSeal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("A valid graph is frozen", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(n int) string { return "" })
+
+		assert.NilError(t, c.Seal())
+		assert.Assert(t, c.Frozen())
+		assert.ErrorIs(t, c.Provide(func() bool { return true }), errs.FrozenError{})
+	})
+
+	t.Run("An invalid graph is reported without freezing", func(t *testing.T) {
+		c := New()
+		c.Provide(func(n int) string { return "" })
+
+		err := c.Seal()
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "int"})
+		assert.Assert(t, !c.Frozen())
+		assert.NilError(t, c.Provide(func() int { return 42 }))
+		assert.NilError(t, c.Seal())
+	})
+}
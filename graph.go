@@ -0,0 +1,520 @@
+package zeus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// Dependencies returns the parameter types of a factory or function, in order.
+// Types supplied by the container itself, such as Hooks, Registry and *slog.Logger, are left out,
+// so the result lists exactly what must be provided for the function to be resolvable.
+// Returns nil if fn is not a function.
+//
+// Example:
+//
+//	deps := zeus.Dependencies(func(h zeus.Hooks, db *sql.DB, cfg Config) *Repository { ... })
+//	fmt.Println(deps) // Outputs: [*sql.DB zeus.Config]
+func Dependencies(fn interface{}) []reflect.Type {
+	fnType := reflect.TypeOf(fn)
+
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil
+	}
+
+	dependencies := []reflect.Type{}
+
+	for i := 0; i < fnType.NumIn(); i++ {
+		if argType := fnType.In(i); !isSupplied(argType) {
+			dependencies = append(dependencies, argType)
+		}
+	}
+
+	return dependencies
+}
+
+// isSupplied reports whether values of the given type are supplied by the container itself instead of a factory.
+func isSupplied(t reflect.Type) bool {
+	return t.Implements(hooksType) || t == registryType || t == warningsType || t == runCacheType || t == loggerType || t == fieldResolverType || t == lifecycleContextType
+}
+
+// factoryDependencies returns the parameter types of a factory that are resolved eagerly from the container.
+// Types supplied by the container itself and lazily resolved ones, such as Provider[T], are skipped.
+func factoryDependencies(factoryType reflect.Type) []reflect.Type {
+	dependencies := []reflect.Type{}
+
+	for i := 0; i < factoryType.NumIn(); i++ {
+		argType := factoryType.In(i)
+
+		if isSupplied(argType) || argType.Implements(injectorType) {
+			continue
+		}
+
+		dependencies = append(dependencies, argType)
+	}
+
+	return dependencies
+}
+
+// dependenciesOf returns the types needed to build the given type, according to its alias, registered factories and decorators.
+// Optional dependencies, such as the optional fields of ProvideStruct, are only listed when something can build them,
+// since they are left to their zero value otherwise, so that Validate does not require them.
+func (c *Container) dependenciesOf(t reflect.Type) []reflect.Type {
+	dependencies, optional := c.wiredDependencies(t)
+
+	for _, dependency := range optional {
+		if _, ok := c.checkDependency(dependency); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies
+}
+
+// wiredDependencies returns the types needed to build the given type as described in dependenciesOf,
+// along with its optional dependencies, whether they can be built or not.
+func (c *Container) wiredDependencies(t reflect.Type) ([]reflect.Type, []reflect.Type) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dependencies := []reflect.Type{}
+	optional := []reflect.Type{}
+	target, aliased := c.aliasOf(t)
+	provider, hasProvider := c.providers[t]
+
+	switch {
+	case aliased:
+		dependencies = append(dependencies, target)
+	case hasProvider:
+		dependencies = append(dependencies, factoryDependencies(provider.Type())...)
+		optional = append(optional, c.optionals[t]...)
+
+		if _, gated := c.flags[t]; gated {
+			dependencies = append(dependencies, featureFlagsType)
+		}
+	default:
+		if t.Kind() == reflect.Map && t.Key() == reflect.TypeOf("") {
+			for _, provider := range c.keyed[t.Elem()] {
+				dependencies = append(dependencies, factoryDependencies(provider.Type())...)
+			}
+		}
+
+		dependencies = append(dependencies, c.bindings[t]...)
+		dependencies = append(dependencies, c.implementations(t)...)
+		dependencies = append(dependencies, c.collected(t)...)
+
+		for _, converter := range c.converters[t] {
+			dependencies = append(dependencies, converter.Type().In(0))
+		}
+	}
+
+	for _, decorator := range c.decorators[t] {
+		for _, dependency := range factoryDependencies(decorator.Type()) {
+			if dependency != t {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+
+	return dependencies, optional
+}
+
+// deferredDependencies returns the types a factory resolves on demand, through parameters such as Provider[T].
+func deferredDependencies(factoryType reflect.Type) []reflect.Type {
+	dependencies := []reflect.Type{}
+
+	for i := 0; i < factoryType.NumIn(); i++ {
+		if argType := factoryType.In(i); argType.Implements(injectorType) {
+			dependencies = append(dependencies, reflect.Zero(argType).Interface().(injector).target())
+		}
+	}
+
+	return dependencies
+}
+
+// requirementsOf returns every type that may be resolved, and whose hooks may be registered, while building the given type:
+// its dependencies, the types ordered before it, the types resolved on demand by its factories,
+// and the other types returned along with it by a MultiReturn factory.
+func (c *Container) requirementsOf(t reflect.Type) []reflect.Type {
+	requirements := append(c.dependenciesOf(t), c.predecessors(t)...)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	factories := slices.Clone(c.decorators[t])
+
+	if provider, exists := c.providers[t]; exists {
+		factories = append(factories, provider)
+		requirements = append(requirements, factoryOutputs(provider.Type())...)
+	} else {
+		factories = append(factories, c.converters[t]...)
+
+		if t.Kind() == reflect.Map && t.Key() == reflect.TypeOf("") {
+			for _, provider := range c.keyed[t.Elem()] {
+				factories = append(factories, provider)
+			}
+		}
+	}
+
+	for _, factory := range factories {
+		requirements = append(requirements, deferredDependencies(factory.Type())...)
+	}
+
+	return requirements
+}
+
+// reachable returns the given types along with every type they require, directly or not, as reported by requirementsOf.
+// Types resolved on demand, such as Provider[T], stand for the type they resolve and supplied types are skipped.
+func (c *Container) reachable(types []reflect.Type) map[reflect.Type]bool {
+	visited := make(map[reflect.Type]bool)
+	pending := slices.Clone(types)
+
+	for len(pending) > 0 {
+		t := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if isSupplied(t) {
+			continue
+		}
+
+		if t.Implements(injectorType) {
+			t = reflect.Zero(t).Interface().(injector).target()
+		}
+
+		if visited[t] {
+			continue
+		}
+
+		visited[t] = true
+		pending = append(pending, c.requirementsOf(t)...)
+	}
+
+	return visited
+}
+
+// detectCycle walks the dependencies of the given type, and the types ordered before it, without building anything.
+// Returns a CyclicDependencyError naming the first type found twice on the same path.
+func (c *Container) detectCycle(t reflect.Type) error {
+	if cycle := c.findCycle(t); cycle != nil {
+		return errs.CyclicDependencyError{TypeName: cycle[0].Name()}
+	}
+
+	return nil
+}
+
+// findCycle returns the types forming the first cycle reachable from the given type, starting with the type
+// found twice on the same path, or nil if there is none.
+func (c *Container) findCycle(t reflect.Type) []reflect.Type {
+	return c.walkCycle(t, nil, map[reflect.Type]bool{})
+}
+
+// walkCycle is the depth-first search behind findCycle.
+// Types on the current path are tracked in path, fully explored ones in visited.
+func (c *Container) walkCycle(t reflect.Type, path []reflect.Type, visited map[reflect.Type]bool) []reflect.Type {
+	if i := slices.Index(path, t); i >= 0 {
+		return slices.Clone(path[i:])
+	}
+
+	if visited[t] {
+		return nil
+	}
+
+	path = append(path, t)
+
+	for _, dependency := range append(c.dependenciesOf(t), c.predecessors(t)...) {
+		if cycle := c.walkCycle(dependency, path, visited); cycle != nil {
+			return cycle
+		}
+	}
+
+	visited[t] = true
+
+	return nil
+}
+
+// Plan returns the types Run would construct to call the given function, in construction order:
+// each type comes after the ones it depends on, following the parameters of the function in order.
+// Like Graph, it only looks at the registered wiring, so types already built are listed too.
+// Types supplied by the container and those resolved on demand, such as Provider[T], are left out.
+// Returns an errs.NotAFunctionError if fn is not a function, or a CyclicDependencyError if the wiring has a cycle.
+//
+// Example:
+//
+//	plan, err := c.Plan(serve)
+//	fmt.Println(plan) // Outputs: [zeus.Config *zeus.Database *zeus.Server]
+func (c *Container) Plan(fn interface{}) ([]reflect.Type, error) {
+	fnType := reflect.TypeOf(fn)
+
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil, errs.NotAFunctionError{}
+	}
+
+	roots := factoryDependencies(fnType)
+
+	for _, t := range roots {
+		if err := c.detectCycle(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.plan(roots), nil
+}
+
+// plan orders the given types and everything they depend on, dependencies first, as described in Plan.
+func (c *Container) plan(roots []reflect.Type) []reflect.Type {
+	order := []reflect.Type{}
+	visited := make(map[reflect.Type]bool)
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+
+		visited[t] = true
+
+		for _, dependency := range append(c.dependenciesOf(t), c.predecessors(t)...) {
+			visit(dependency)
+		}
+
+		order = append(order, t)
+	}
+
+	for _, t := range roots {
+		visit(t)
+	}
+
+	return order
+}
+
+// Graph returns the dependency graph of the container as an adjacency list.
+// Each type the container can build is mapped to the types it depends on, sorted by name and without duplicates.
+// Instances are not taken into account, only the registered factories, decorators, bindings and converters.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() int { return 42 })
+//	c.Provide(func(i int) string { return fmt.Sprint(i) })
+//	fmt.Println(c.Graph()) // Outputs: map[int:[] string:[int]]
+func (c *Container) Graph() map[reflect.Type][]reflect.Type {
+	graph := make(map[reflect.Type][]reflect.Type)
+
+	for _, t := range c.Types() {
+		graph[t] = sortTypes(c.dependenciesOf(t))
+	}
+
+	return graph
+}
+
+// GraphEqual reports whether two containers have the same dependency graph:
+// the same buildable types, each depending on the same types.
+// Instance state is ignored, so a container that already built its values equals a fresh one with the same wiring.
+//
+// Example:
+//
+//	assert.True(t, zeus.GraphEqual(legacyModule(), refactoredModule()))
+func GraphEqual(a, b *Container) bool {
+	graphA, graphB := a.Graph(), b.Graph()
+
+	if len(graphA) != len(graphB) {
+		return false
+	}
+
+	for t, edgesA := range graphA {
+		edgesB, exists := graphB[t]
+
+		if !exists || !slices.Equal(edgesA, edgesB) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Edge is a dependency edge of the graph: From depends on To.
+type Edge struct {
+	From reflect.Type
+	To   reflect.Type
+}
+
+// String returns the edge as "from -> to".
+func (e Edge) String() string {
+	return fmt.Sprintf("%s -> %s", e.From, e.To)
+}
+
+// Diff lists the differences between two dependency graphs, as reported by GraphDiff.
+// Every list is sorted by name, so that diffs of the same graphs are always equal and print the same.
+type Diff struct {
+	// Added lists the types only the container after the change can build.
+	Added []reflect.Type
+	// Removed lists the types only the container before the change can build.
+	Removed []reflect.Type
+	// AddedEdges lists the dependencies gained by types both containers can build.
+	AddedEdges []Edge
+	// RemovedEdges lists the dependencies lost by types both containers can build.
+	RemovedEdges []Edge
+}
+
+// String returns the diff one change per line, prefixed with "+" for additions and "-" for removals:
+// added types, removed types, then added and removed edges. An empty diff returns an empty string.
+func (d Diff) String() string {
+	var builder strings.Builder
+
+	for _, t := range d.Added {
+		fmt.Fprintf(&builder, "+ %s\n", t)
+	}
+
+	for _, t := range d.Removed {
+		fmt.Fprintf(&builder, "- %s\n", t)
+	}
+
+	for _, edge := range d.AddedEdges {
+		fmt.Fprintf(&builder, "+ %s\n", edge)
+	}
+
+	for _, edge := range d.RemovedEdges {
+		fmt.Fprintf(&builder, "- %s\n", edge)
+	}
+
+	return builder.String()
+}
+
+// GraphDiff compares the dependency graphs of two containers, to review how a change affects the wiring.
+// It reports the types added and removed between before and after, and the dependency edges that changed
+// for types present in both. The edges of added and removed types are implied by the types themselves and left out.
+// Instance state is ignored, like in GraphEqual.
+//
+// Example:
+//
+//	fmt.Print(zeus.GraphDiff(before, after))
+//	// + *Cache
+//	// + *Service -> *Cache
+func GraphDiff(before, after *Container) Diff {
+	graphOld, graphNew := before.Graph(), after.Graph()
+	diff := Diff{}
+
+	for t, edgesNew := range graphNew {
+		edgesOld, exists := graphOld[t]
+
+		if !exists {
+			diff.Added = append(diff.Added, t)
+			continue
+		}
+
+		for _, dependency := range edgesNew {
+			if !slices.Contains(edgesOld, dependency) {
+				diff.AddedEdges = append(diff.AddedEdges, Edge{From: t, To: dependency})
+			}
+		}
+
+		for _, dependency := range edgesOld {
+			if !slices.Contains(edgesNew, dependency) {
+				diff.RemovedEdges = append(diff.RemovedEdges, Edge{From: t, To: dependency})
+			}
+		}
+	}
+
+	for t := range graphOld {
+		if _, exists := graphNew[t]; !exists {
+			diff.Removed = append(diff.Removed, t)
+		}
+	}
+
+	diff.Added = sortTypes(diff.Added)
+	diff.Removed = sortTypes(diff.Removed)
+	sortEdges(diff.AddedEdges)
+	sortEdges(diff.RemovedEdges)
+
+	return diff
+}
+
+// sortEdges sorts the edges by the name of the dependent type, then of the dependency.
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].String() < edges[j].String()
+	})
+}
+
+// FanIn returns, for each type appearing in the dependency graph, how many buildable types depend on it directly.
+// Types with a high fan-in are shared by many others and are good candidates for optimization.
+// Types nothing depends on are reported with zero.
+//
+// Example:
+//
+//	for t, n := range c.FanIn() {
+//	    fmt.Printf("%s is used by %d types\n", t, n)
+//	}
+func (c *Container) FanIn() map[reflect.Type]int {
+	fanIn := make(map[reflect.Type]int)
+
+	for t, dependencies := range c.Graph() {
+		if _, exists := fanIn[t]; !exists {
+			fanIn[t] = 0
+		}
+
+		for _, dependency := range dependencies {
+			fanIn[dependency]++
+		}
+	}
+
+	return fanIn
+}
+
+// GraphHash returns a stable hexadecimal SHA-256 hash of the dependency graph.
+// It covers the buildable types and their dependency edges, by name, so it only changes when the wiring does.
+// Instance state is ignored, like in GraphEqual.
+//
+// Example:
+//
+//	if c.GraphHash() == cachedHash {
+//	    return // wiring unchanged
+//	}
+func (c *Container) GraphHash() string {
+	graph := c.Graph()
+	types := make([]reflect.Type, 0, len(graph))
+
+	for t := range graph {
+		types = append(types, t)
+	}
+
+	hash := sha256.New()
+
+	for _, t := range sortTypes(types) {
+		fmt.Fprintf(hash, "%s:", t)
+
+		for _, dependency := range graph[t] {
+			fmt.Fprintf(hash, " %s", dependency)
+		}
+
+		fmt.Fprintln(hash)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// sortTypes sorts the types by name and removes duplicates.
+func sortTypes(types []reflect.Type) []reflect.Type {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	return slices.Compact(types)
+}
+
+// typeNames returns the string representation of each type.
+func typeNames(types []reflect.Type) []string {
+	names := make([]string, len(types))
+
+	for i, t := range types {
+		names[i] = t.String()
+	}
+
+	return names
+}
@@ -0,0 +1,227 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Dependencies", func(t *testing.T) {
+		t.Run("Mixed parameters", func(t *testing.T) {
+			got := Dependencies(func(h Hooks, i int, r Registry, b *strings.Builder, p Provider[bool]) string {
+				return ""
+			})
+			expected := []reflect.Type{
+				reflect.TypeOf(0),
+				reflect.TypeOf(&strings.Builder{}),
+				reflect.TypeOf(Provider[bool](nil)),
+			}
+
+			assert.Assert(t, slices.Equal(got, expected))
+		})
+
+		t.Run("No parameters", func(t *testing.T) {
+			got := Dependencies(func() int { return 42 })
+			assert.Equal(t, len(got), 0)
+		})
+
+		t.Run("Not a function", func(t *testing.T) {
+			assert.Assert(t, Dependencies("string") == nil)
+			assert.Assert(t, Dependencies(nil) == nil)
+		})
+	})
+
+	t.Run("Graph", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int, b bool, other int) string { return "" })
+		c.Provide(func(h Hooks) bool { return true })
+
+		graph := c.Graph()
+
+		assert.Equal(t, len(graph), 3)
+		assert.Equal(t, len(graph[reflect.TypeOf(0)]), 0)
+		assert.Equal(t, len(graph[reflect.TypeOf(true)]), 0)
+		assert.Assert(t, slices.Equal(graph[reflect.TypeOf("")], []reflect.Type{reflect.TypeOf(true), reflect.TypeOf(0)}))
+	})
+
+	t.Run("GraphEqual", func(t *testing.T) {
+		newContainer := func(stringFactory interface{}) *Container {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func() bool { return true })
+			c.Provide(stringFactory)
+			return c
+		}
+
+		t.Run("Equivalent wiring", func(t *testing.T) {
+			a := newContainer(func(i int) string { return "a" })
+			b := newContainer(func(i int) string { return "b" })
+
+			b.Run(func(s string) {})
+
+			assert.Assert(t, GraphEqual(a, b))
+		})
+
+		t.Run("Different edge", func(t *testing.T) {
+			a := newContainer(func(i int) string { return "a" })
+			b := newContainer(func(b bool) string { return "b" })
+
+			assert.Assert(t, !GraphEqual(a, b))
+		})
+
+		t.Run("Different types", func(t *testing.T) {
+			a := newContainer(func(i int) string { return "a" })
+			b := newContainer(func(i int) string { return "b" })
+			b.Provide(func() float64 { return 1 })
+
+			assert.Assert(t, !GraphEqual(a, b))
+			assert.Assert(t, !GraphEqual(b, a))
+		})
+	})
+
+	t.Run("GraphHash", func(t *testing.T) {
+		newContainer := func(stringFactory interface{}) *Container {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func() bool { return true })
+			c.Provide(stringFactory)
+			return c
+		}
+
+		t.Run("Stable for the same wiring", func(t *testing.T) {
+			a := newContainer(func(i int) string { return "a" })
+			b := newContainer(func(i int) string { return "b" })
+
+			b.Run(func(s string) {})
+
+			assert.Equal(t, a.GraphHash(), a.GraphHash())
+			assert.Equal(t, a.GraphHash(), b.GraphHash())
+		})
+
+		t.Run("Changes with an edge", func(t *testing.T) {
+			a := newContainer(func(i int) string { return "a" })
+			b := newContainer(func(b bool) string { return "b" })
+
+			assert.Assert(t, a.GraphHash() != b.GraphHash())
+		})
+
+		t.Run("Changes with a provider", func(t *testing.T) {
+			c := newContainer(func(i int) string { return "a" })
+			before := c.GraphHash()
+			c.Provide(func() float64 { return 1 })
+
+			assert.Assert(t, before != c.GraphHash())
+		})
+	})
+
+	t.Run("GraphDiff", func(t *testing.T) {
+		newContainer := func(stringFactory interface{}) *Container {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func() bool { return true })
+			c.Provide(stringFactory)
+			return c
+		}
+
+		t.Run("Added provider and changed edge", func(t *testing.T) {
+			before := newContainer(func(i int) string { return "a" })
+			after := newContainer(func(b bool) string { return "b" })
+			after.Provide(func(s string) float64 { return 1 })
+
+			diff := GraphDiff(before, after)
+			assert.DeepEqual(t, typeNames(diff.Added), []string{"float64"})
+			assert.Equal(t, len(diff.Removed), 0)
+			assert.Equal(t, len(diff.AddedEdges), 1)
+			assert.Equal(t, diff.AddedEdges[0], Edge{From: reflect.TypeOf(""), To: reflect.TypeOf(true)})
+			assert.Equal(t, len(diff.RemovedEdges), 1)
+			assert.Equal(t, diff.RemovedEdges[0], Edge{From: reflect.TypeOf(""), To: reflect.TypeOf(0)})
+			assert.Equal(t, diff.String(), "+ float64\n+ string -> bool\n- string -> int\n")
+		})
+
+		t.Run("Removed provider", func(t *testing.T) {
+			before := newContainer(func(i int) string { return "a" })
+			before.Provide(func() float64 { return 1 })
+			after := newContainer(func(i int) string { return "a" })
+
+			diff := GraphDiff(before, after)
+			assert.DeepEqual(t, typeNames(diff.Removed), []string{"float64"})
+			assert.Equal(t, diff.String(), "- float64\n")
+		})
+
+		t.Run("Same wiring", func(t *testing.T) {
+			before := newContainer(func(i int) string { return "a" })
+			after := newContainer(func(i int) string { return "b" })
+
+			after.Run(func(s string) {})
+
+			assert.DeepEqual(t, GraphDiff(before, after), Diff{})
+			assert.Equal(t, GraphDiff(before, after).String(), "")
+		})
+	})
+
+	t.Run("FanIn", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int) string { return "Hello" })
+		c.Provide(func(i int) bool { return true })
+		c.Provide(func(i int, s string) float64 { return 1 })
+
+		fanIn := c.FanIn()
+
+		assert.Equal(t, fanIn[reflect.TypeOf(0)], 3)
+		assert.Equal(t, fanIn[reflect.TypeOf("")], 1)
+		assert.Equal(t, fanIn[reflect.TypeOf(0.0)], 0)
+
+		_, exists := fanIn[reflect.TypeOf(0.0)]
+		assert.Assert(t, exists)
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		type Config struct{}
+		type Database struct{}
+		type Cache struct{}
+		type Server struct{}
+
+		newContainer := func() *Container {
+			c := New()
+			c.Provide(func(cfg Config, db *Database, cache *Cache) *Server { return &Server{} })
+			c.Provide(func(h Hooks, cfg Config) *Database { return &Database{} })
+			c.Provide(func(p Provider[*Server], cfg Config) *Cache { return &Cache{} })
+			c.Provide(func() Config { return Config{} })
+			return c
+		}
+
+		t.Run("Lists dependencies first", func(t *testing.T) {
+			plan, err := newContainer().Plan(func(s *Server, h Hooks) {})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, typeNames(plan), []string{"zeus.Config", "*zeus.Database", "*zeus.Cache", "*zeus.Server"})
+		})
+
+		t.Run("Follows the parameters in order", func(t *testing.T) {
+			plan, err := newContainer().Plan(func(cache *Cache, db *Database) {})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, typeNames(plan), []string{"zeus.Config", "*zeus.Cache", "*zeus.Database"})
+		})
+
+		t.Run("Reports cycles", func(t *testing.T) {
+			c := New()
+			c.Provide(func(s string) int { return 0 }, func(i int) string { return "" })
+
+			_, err := c.Plan(func(int) {})
+			assert.ErrorIs(t, err, errs.CyclicDependencyError{TypeName: "int"})
+		})
+
+		t.Run("Not a function", func(t *testing.T) {
+			_, err := New().Plan(42)
+			assert.ErrorIs(t, err, errs.NotAFunctionError{})
+		})
+	})
+}
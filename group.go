@@ -0,0 +1,171 @@
+package zeus
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/otoru/zeus/errs"
+	"github.com/otoru/zeus/hooks"
+)
+
+// groupMember is a factory registered in a group with the Group option, along with the value it built.
+type groupMember struct {
+	factory  reflect.Value
+	priority int
+	value    reflect.Value
+}
+
+// ResolveGroup constructs the members of the named group whose type matches the sample,
+// by decreasing priority and then in registration order.
+// Members returning an interface implemented by the sample's type, or the type itself, match.
+// The sample is a value of the type, or a pointer to it for interfaces.
+// Each member is built once; the successful ones are returned even when others fail,
+// and the failures are aggregated in the returned error.
+//
+// Example:
+//
+//	plugins, err := c.ResolveGroup("plugins", (*Plugin)(nil))
+//	if err != nil {
+//	    log.Println("some plugins failed to load:", err)
+//	}
+func (c *Container) ResolveGroup(group string, elemSample interface{}) ([]interface{}, error) {
+	return c.resolveGroup(group, sampleType(elemSample))
+}
+
+// resolveGroup constructs the members of the named group assignable to the given type, as described in ResolveGroup.
+func (c *Container) resolveGroup(group string, elemType reflect.Type) ([]interface{}, error) {
+	errorSet := &errs.ErrorSet{}
+	values := []interface{}{}
+
+	for _, member := range c.groupMembers(group, elemType) {
+		value, err := c.buildMember(member)
+
+		if err != nil {
+			errorSet.Add(err)
+			continue
+		}
+
+		values = append(values, value.Interface())
+	}
+
+	return values, errorSet.Result()
+}
+
+// MustGroup returns the members of the named group that are assignable to T, typed, like ResolveGroup.
+// It panics with the aggregated error if any member fails to build, and returns an empty slice for an empty group.
+//
+// Example:
+//
+//	for _, plugin := range zeus.MustGroup[Plugin](c, "plugins") {
+//	    plugin.Register(mux)
+//	}
+func MustGroup[T any](c *Container, name string) []T {
+	values, err := c.resolveGroup(name, typeOf[T]())
+
+	if err != nil {
+		panic(err)
+	}
+
+	members := make([]T, len(values))
+
+	for i, value := range values {
+		members[i] = value.(T)
+	}
+
+	return members
+}
+
+// Groups returns each named group along with the types its members return, in the order ResolveGroup builds them:
+// by decreasing priority, then in registration order. A type appears once per member returning it.
+// Nothing is built, so it helps checking that plugins joined the right group.
+//
+// Example:
+//
+//	for group, types := range c.Groups() {
+//	    fmt.Println(group, types)
+//	}
+func (c *Container) Groups() map[string][]reflect.Type {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.groups))
+
+	for group := range c.groups {
+		names = append(names, group)
+	}
+
+	c.mu.RUnlock()
+
+	groups := make(map[string][]reflect.Type, len(names))
+
+	for _, group := range names {
+		members := c.groupMembers(group, typeOf[interface{}]())
+		types := make([]reflect.Type, len(members))
+
+		for i, member := range members {
+			types[i] = member.factory.Type().Out(0)
+		}
+
+		groups[group] = types
+	}
+
+	return groups
+}
+
+// groupMembers returns the members of the named group producing values assignable to the given type, sorted by priority.
+func (c *Container) groupMembers(group string, elemType reflect.Type) []*groupMember {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	members := []*groupMember{}
+
+	for _, member := range c.groups[group] {
+		if member.factory.Type().Out(0).AssignableTo(elemType) {
+			members = append(members, member)
+		}
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].priority > members[j].priority
+	})
+
+	return members
+}
+
+// buildMember returns the value of a group member, calling its factory the first time.
+// As with any other construction, the hooks registered by the factory are kept only when it succeeds.
+func (c *Container) buildMember(member *groupMember) (reflect.Value, error) {
+	c.mu.RLock()
+	value := member.value
+	c.mu.RUnlock()
+
+	if value.IsValid() {
+		return value, nil
+	}
+
+	stage := new(hooks.LifecycleHooks)
+	value, err := c.invoke(member.factory, nil, nil, stage)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	c.record(nil, stage)
+
+	if !c.prototype {
+		c.mu.Lock()
+		member.value = value
+		c.mu.Unlock()
+	}
+
+	return value, nil
+}
+
+// mergeGroups appends the group members of another container after the members of the current one,
+// so that groups keep the merge order, then the registration order. Members are built again by the current container.
+// The caller must hold the lock of the current container for writing and the lock of the other one for reading.
+func (c *Container) mergeGroups(other *Container) {
+	for group, members := range other.groups {
+		for _, member := range members {
+			c.groups[group] = append(c.groups[group], &groupMember{factory: member.factory, priority: member.priority})
+		}
+	}
+}
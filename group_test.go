@@ -0,0 +1,201 @@
+package zeus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ResolveGroup", func(t *testing.T) {
+		t.Run("Returns every member", func(t *testing.T) {
+			c := New()
+			err := c.Provide(
+				func() string { return "auth" },
+				func() string { return "metrics" },
+				Group("plugins"),
+			)
+			assert.NilError(t, err)
+
+			plugins, err := c.ResolveGroup("plugins", "")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, plugins, []interface{}{"auth", "metrics"})
+		})
+
+		t.Run("Members are not registered as providers", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "auth" }, Group("plugins"))
+
+			err := c.Provide(func() string { return "standalone" })
+			assert.NilError(t, err)
+
+			plugins, err := c.ResolveGroup("plugins", "")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, plugins, []interface{}{"auth"})
+		})
+
+		t.Run("Members are built once", func(t *testing.T) {
+			c := New()
+			calls := 0
+			c.Provide(func() int { calls++; return calls }, Group("counters"))
+
+			c.ResolveGroup("counters", 0)
+			plugins, err := c.ResolveGroup("counters", 0)
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, plugins, []interface{}{1})
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Filters members by type", func(t *testing.T) {
+			c := New()
+			c.Provide(
+				func() *strings.Builder { return &strings.Builder{} },
+				func() int { return 42 },
+				Group("plugins"),
+			)
+
+			plugins, err := c.ResolveGroup("plugins", new(fmt.Stringer))
+			assert.NilError(t, err)
+			assert.Equal(t, len(plugins), 1)
+
+			_, ok := plugins[0].(*strings.Builder)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("Returns the successful members along with the errors", func(t *testing.T) {
+			c := New()
+			expected := errors.New("plugin error")
+			c.Provide(
+				func() string { return "auth" },
+				func() (string, error) { return "", expected },
+				func() string { return "metrics" },
+				Group("plugins"),
+			)
+
+			plugins, err := c.ResolveGroup("plugins", "")
+			assert.ErrorIs(t, err, expected)
+			assert.DeepEqual(t, plugins, []interface{}{"auth", "metrics"})
+		})
+
+		t.Run("Orders members by priority", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "logging" }, Group("middleware"))
+			c.Provide(func() string { return "auth" }, Group("middleware"), Priority(10))
+			c.Provide(func() string { return "metrics" }, Group("middleware"), Priority(5))
+			c.Provide(func() string { return "tracing" }, Group("middleware"), Priority(5))
+
+			middleware, err := c.ResolveGroup("middleware", "")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, middleware, []interface{}{"auth", "metrics", "tracing", "logging"})
+		})
+
+		t.Run("Unknown group", func(t *testing.T) {
+			c := New()
+			plugins, err := c.ResolveGroup("plugins", "")
+
+			assert.NilError(t, err)
+			assert.Equal(t, len(plugins), 0)
+		})
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		newContainers := func() (*Container, *Container) {
+			a, b := New(), New()
+			a.Provide(func() string { return "a1" }, func() string { return "a2" }, Group("plugins"))
+			b.Provide(func() string { return "b1" }, Group("plugins"))
+			b.Provide(func() string { return "b2" }, Group("plugins"))
+			return a, b
+		}
+
+		t.Run("Members of both containers are combined", func(t *testing.T) {
+			a, b := newContainers()
+
+			err := a.Merge(b)
+			assert.NilError(t, err)
+
+			plugins, err := a.ResolveGroup("plugins", "")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, plugins, []interface{}{"a1", "a2", "b1", "b2"})
+		})
+
+		t.Run("MergeAll combines members too", func(t *testing.T) {
+			a, b := newContainers()
+
+			err := b.MergeAll(a)
+			assert.NilError(t, err)
+
+			plugins, err := b.ResolveGroup("plugins", "")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, plugins, []interface{}{"b1", "b2", "a1", "a2"})
+		})
+	})
+
+	t.Run("MustGroup", func(t *testing.T) {
+		t.Run("Returns the typed members", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "auth" }, func() string { return "metrics" }, Group("plugins"))
+
+			assert.DeepEqual(t, MustGroup[string](c, "plugins"), []string{"auth", "metrics"})
+		})
+
+		t.Run("Interface members", func(t *testing.T) {
+			c := New()
+			c.Provide(func() *strings.Builder { return &strings.Builder{} }, Group("plugins"))
+
+			assert.Equal(t, len(MustGroup[fmt.Stringer](c, "plugins")), 1)
+		})
+
+		t.Run("Empty group", func(t *testing.T) {
+			c := New()
+
+			assert.DeepEqual(t, MustGroup[string](c, "plugins"), []string{})
+		})
+
+		t.Run("Panics on construction errors", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (string, error) { return "", errors.New("plugin error") }, Group("plugins"))
+
+			defer func() {
+				err, ok := recover().(error)
+				assert.Assert(t, ok)
+				assert.ErrorContains(t, err, "plugin error")
+			}()
+
+			MustGroup[string](c, "plugins")
+		})
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		t.Run("Lists the members of each group", func(t *testing.T) {
+			c := New()
+			c.Provide(func() string { return "auth" }, func() *strings.Builder { return &strings.Builder{} }, Group("plugins"))
+			c.Provide(func() int { return 1 }, Group("metrics"))
+			c.Provide(func() float64 { return 1 }, Group("plugins"), Priority(1))
+			c.Provide(func() bool { return true })
+
+			groups := c.Groups()
+			assert.Equal(t, len(groups), 2)
+			assert.DeepEqual(t, typeNames(groups["plugins"]), []string{"float64", "string", "*strings.Builder"})
+			assert.DeepEqual(t, typeNames(groups["metrics"]), []string{"int"})
+		})
+
+		t.Run("Builds nothing", func(t *testing.T) {
+			c := New()
+			calls := 0
+			c.Provide(func() string { calls++; return "auth" }, Group("plugins"))
+
+			assert.Equal(t, len(c.Groups()["plugins"]), 1)
+			assert.Equal(t, calls, 0)
+		})
+
+		t.Run("Empty without groups", func(t *testing.T) {
+			assert.Equal(t, len(New().Groups()), 0)
+		})
+	})
+}
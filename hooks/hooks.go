@@ -1,6 +1,13 @@
 package hooks
 
-import "sync"
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+)
 
 // Hooks defines an interface for lifecycle events.
 // It provides methods to register functions that should be executed
@@ -12,10 +19,24 @@ type Hooks interface {
 	Stop() error
 }
 
+// RetryHooks is implemented by the Hooks that can retry their start hooks, such as LifecycleHooks.
+// It is kept apart from Hooks so that existing implementations of Hooks still satisfy it:
+// check for it with a type assertion.
+type RetryHooks interface {
+	OnStartRetry(fn func() error, attempts int, backoff time.Duration)
+}
+
+// ContextHooks is implemented by the Hooks whose stop hooks can receive a context, such as LifecycleHooks.
+// Like RetryHooks, it is kept apart from Hooks: check for it with a type assertion.
+type ContextHooks interface {
+	OnStopContext(func(ctx context.Context) error)
+	StopContext(ctx context.Context) error
+}
+
 // LifecycleHooks is the default implementation of the Hooks interface.
 type LifecycleHooks struct {
 	onStart []func() error
-	onStop  []func() error
+	onStop  []func(context.Context) error
 	mu      sync.Mutex
 }
 
@@ -32,6 +53,32 @@ func (h *LifecycleHooks) OnStart(fn func() error) {
 	h.onStart = append(h.onStart, fn)
 }
 
+// OnStartRetry adds a function to be executed at the start, retrying it on failure.
+// The function is called up to attempts times, waiting backoff between consecutive calls.
+// The error of the last attempt is returned by Start if every attempt fails.
+// Example:
+//
+//	hooks.OnStartRetry(func() error {
+//	   return db.Ping()
+//	}, 3, time.Second)
+func (h *LifecycleHooks) OnStartRetry(fn func() error, attempts int, backoff time.Duration) {
+	h.OnStart(func() error {
+		var err error
+
+		for attempt := 0; attempt < max(attempts, 1); attempt++ {
+			if attempt > 0 {
+				time.Sleep(backoff)
+			}
+
+			if err = fn(); err == nil {
+				return nil
+			}
+		}
+
+		return err
+	})
+}
+
 // OnStop adds a function to the list of functions to be executed at the stop.
 // Example:
 //
@@ -40,11 +87,54 @@ func (h *LifecycleHooks) OnStart(fn func() error) {
 //	   return nil
 //	})
 func (h *LifecycleHooks) OnStop(fn func() error) {
+	h.OnStopContext(func(context.Context) error {
+		return fn()
+	})
+}
+
+// OnStopContext adds a context-aware function to the list of functions to be executed at the stop.
+// The function receives the context given to StopContext, which carries the shutdown deadline.
+// Example:
+//
+//	hooks.OnStopContext(func(ctx context.Context) error {
+//	   return server.Shutdown(ctx)
+//	})
+func (h *LifecycleHooks) OnStopContext(fn func(ctx context.Context) error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.onStop = append(h.onStop, fn)
 }
 
+// Append registers the hooks of other after the ones of h, keeping their order.
+// It is used to commit hooks staged during a construction once it succeeded.
+func (h *LifecycleHooks) Append(other *LifecycleHooks) {
+	other.mu.Lock()
+	onStart := slices.Clone(other.onStart)
+	onStop := slices.Clone(other.onStop)
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onStart = append(h.onStart, onStart...)
+	h.onStop = append(h.onStop, onStop...)
+}
+
+// HasStartHooks reports whether any OnStart hook is registered.
+func (h *LifecycleHooks) HasStartHooks() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.onStart) > 0
+}
+
+// HasStopHooks reports whether any OnStop hook is registered.
+func (h *LifecycleHooks) HasStopHooks() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.onStop) > 0
+}
+
 // Start executes all the registered OnStart hooks.
 // It returns the first error encountered or nil if all hooks execute successfully.
 // This method is internally used by the Container's Run function.
@@ -57,15 +147,38 @@ func (h *LifecycleHooks) Start() error {
 	return nil
 }
 
-// Stop executes all the registered OnStop hooks.
-// It returns the first error encountered or nil if all hooks execute successfully.
+// Stop executes all the registered OnStop hooks with a background context.
+// Every hook runs even if a previous one failed or panicked, so all resources get a chance to be released.
+// Panics are recovered and reported as errs.PanicError.
+// It returns the aggregated errors or nil if all hooks execute successfully.
 // This method is internally used by the Container's Run function.
 func (h *LifecycleHooks) Stop() error {
+	return h.StopContext(context.Background())
+}
+
+// StopContext executes all the registered OnStop hooks like Stop,
+// passing the given context to the hooks registered with OnStopContext.
+func (h *LifecycleHooks) StopContext(ctx context.Context) error {
+	errorSet := &errs.ErrorSet{}
+
 	for _, hook := range h.onStop {
-		if err := hook(); err != nil {
-			return err
+		run := func() error { return hook(ctx) }
+
+		if err := runRecovered(run, "stop hook"); err != nil {
+			errorSet.Add(err)
 		}
 	}
 
-	return nil
+	return errorSet.Result()
+}
+
+// runRecovered executes the hook, converting a panic into an errs.PanicError.
+func runRecovered(hook func() error, source string) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = errs.PanicError{Source: source, Value: value}
+		}
+	}()
+
+	return hook()
 }
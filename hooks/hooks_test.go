@@ -1,9 +1,13 @@
 package hooks
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/otoru/zeus/errs"
 	"gotest.tools/v3/assert"
 )
 
@@ -19,6 +23,41 @@ func TestHooksImpl(t *testing.T) {
 		})
 	})
 
+	t.Run("OnStartRetry", func(t *testing.T) {
+		t.Run("should be available through RetryHooks", func(t *testing.T) {
+			var h Hooks = &LifecycleHooks{}
+			_, ok := h.(RetryHooks)
+			assert.Assert(t, ok)
+		})
+
+		t.Run("should succeed after transient failures", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			calls := 0
+			h.OnStartRetry(func() error {
+				calls++
+				if calls < 3 {
+					return errors.New("not ready")
+				}
+				return nil
+			}, 5, time.Millisecond)
+			err := h.Start()
+			assert.NilError(t, err)
+			assert.Equal(t, calls, 3)
+		})
+
+		t.Run("should return the last error after exhausting attempts", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			calls := 0
+			h.OnStartRetry(func() error {
+				calls++
+				return fmt.Errorf("attempt %d failed", calls)
+			}, 4, time.Millisecond)
+			err := h.Start()
+			assert.Error(t, err, "attempt 4 failed")
+			assert.Equal(t, calls, 4)
+		})
+	})
+
 	t.Run("OnStop", func(t *testing.T) {
 		h := &LifecycleHooks{}
 
@@ -30,6 +69,69 @@ func TestHooksImpl(t *testing.T) {
 		})
 	})
 
+	t.Run("OnStopContext", func(t *testing.T) {
+		t.Run("should be available through ContextHooks", func(t *testing.T) {
+			var h Hooks = &LifecycleHooks{}
+			_, ok := h.(ContextHooks)
+			assert.Assert(t, ok)
+		})
+
+		h := &LifecycleHooks{}
+
+		t.Run("should add function to onStop slice", func(t *testing.T) {
+			h.OnStopContext(func(ctx context.Context) error {
+				return nil
+			})
+			assert.Equal(t, len(h.onStop), 1)
+		})
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		t.Run("should register the other hooks in order", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			other := &LifecycleHooks{}
+			calls := []string{}
+			h.OnStart(func() error {
+				calls = append(calls, "first")
+				return nil
+			})
+			other.OnStart(func() error {
+				calls = append(calls, "second")
+				return nil
+			})
+			other.OnStop(func() error {
+				calls = append(calls, "stop")
+				return nil
+			})
+			h.Append(other)
+			assert.NilError(t, h.Start())
+			assert.NilError(t, h.Stop())
+			assert.DeepEqual(t, calls, []string{"first", "second", "stop"})
+		})
+	})
+
+	t.Run("HasStartHooks", func(t *testing.T) {
+		t.Run("should report registered start hooks only", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			h.OnStop(func() error { return nil })
+			assert.Assert(t, !h.HasStartHooks())
+
+			h.OnStart(func() error { return nil })
+			assert.Assert(t, h.HasStartHooks())
+		})
+	})
+
+	t.Run("HasStopHooks", func(t *testing.T) {
+		t.Run("should report registered stop hooks only", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			h.OnStart(func() error { return nil })
+			assert.Assert(t, !h.HasStopHooks())
+
+			h.OnStopContext(func(context.Context) error { return nil })
+			assert.Assert(t, h.HasStopHooks())
+		})
+	})
+
 	t.Run("Start", func(t *testing.T) {
 		t.Run("should execute all onStart hooks without error", func(t *testing.T) {
 			h := &LifecycleHooks{}
@@ -80,5 +182,50 @@ func TestHooksImpl(t *testing.T) {
 			err := h.Stop()
 			assert.ErrorContains(t, err, "stop error")
 		})
+
+		t.Run("should pass the context to context-aware hooks", func(t *testing.T) {
+			type key struct{}
+			h := &LifecycleHooks{}
+			var got interface{}
+			h.OnStopContext(func(ctx context.Context) error {
+				got = ctx.Value(key{})
+				return nil
+			})
+			err := h.StopContext(context.WithValue(context.Background(), key{}, "value"))
+			assert.NilError(t, err)
+			assert.Equal(t, got, "value")
+		})
+
+		t.Run("should run remaining hooks when one panics", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			ran := []int{}
+			h.OnStop(func() error {
+				panic("boom")
+			})
+			h.OnStop(func() error {
+				ran = append(ran, 2)
+				return nil
+			})
+			h.OnStop(func() error {
+				ran = append(ran, 3)
+				return nil
+			})
+			err := h.Stop()
+			assert.DeepEqual(t, ran, []int{2, 3})
+			assert.ErrorIs(t, err, errs.PanicError{Source: "stop hook", Value: "boom"})
+		})
+
+		t.Run("should aggregate errors from every failing hook", func(t *testing.T) {
+			h := &LifecycleHooks{}
+			h.OnStop(func() error {
+				return errors.New("first error")
+			})
+			h.OnStop(func() error {
+				return errors.New("second error")
+			})
+			err := h.Stop()
+			assert.ErrorContains(t, err, "first error")
+			assert.ErrorContains(t, err, "second error")
+		})
 	})
 }
@@ -0,0 +1,91 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// returnTypes returns the types produced by the given factories, leaving out trailing errors and anything that is not a function.
+func returnTypes(factories []interface{}) []reflect.Type {
+	types := []reflect.Type{}
+
+	for _, factory := range factories {
+		if factoryType := reflect.TypeOf(factory); factoryType != nil && factoryType.Kind() == reflect.Func {
+			types = append(types, factoryOutputs(factoryType)...)
+		}
+	}
+
+	return types
+}
+
+// stale applies the policy set with OnLateProvide before types are registered.
+// It returns the cached types that depend on the given ones, to be invalidated once the registration succeeds,
+// or an errs.LateProvideError if the policy rejects late registrations.
+// With InterfaceFallback, cached interfaces implemented by one of the types are taken into account too.
+// Returns an errs.FrozenError instead if the container has been frozen.
+func (c *Container) stale(types []reflect.Type) ([]reflect.Type, error) {
+	if err := c.checkFrozen(); err != nil {
+		return nil, err
+	}
+
+	if c.late == LateIgnore || len(types) == 0 {
+		return nil, nil
+	}
+
+	changed := slices.Clone(types)
+
+	c.mu.RLock()
+	for _, cached := range c.heldTypes() {
+		if !c.fallback || cached.Kind() != reflect.Interface {
+			continue
+		}
+
+		for _, t := range types {
+			if t != cached && t.Implements(cached) {
+				changed = append(changed, cached)
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	affected := c.dependents(changed)
+	stale := []reflect.Type{}
+
+	c.mu.RLock()
+	for t := range affected {
+		if _, exists := c.cached(t); exists {
+			stale = append(stale, t)
+		}
+	}
+	c.mu.RUnlock()
+
+	stale = sortTypes(stale)
+
+	if len(stale) > 0 && c.late == LateError {
+		return nil, errs.LateProvideError{TypeName: types[0].String(), ResolvedName: stale[0].String()}
+	}
+
+	return stale, nil
+}
+
+// invalidate drops the cached values of the given types, along with the hooks recorded while building them,
+// so that they are rebuilt on their next resolution. The caller must hold the lock for writing.
+func (c *Container) invalidate(types []reflect.Type) {
+	if len(types) == 0 {
+		return
+	}
+
+	for _, t := range types {
+		c.uncache(t)
+
+		if provider, exists := c.providers[t]; exists {
+			delete(c.tuples, provider.Type())
+		}
+	}
+
+	c.records = slices.DeleteFunc(c.records, func(record hookRecord) bool {
+		return record.t != nil && slices.Contains(types, record.t)
+	})
+}
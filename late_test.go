@@ -0,0 +1,91 @@
+package zeus
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestLateProvide(t *testing.T) {
+	t.Parallel()
+
+	newContainer := func(policy LatePolicy) *Container {
+		c := New(OnLateProvide(policy))
+		c.ProvideKeyed("b", func() int { return 1 })
+		c.Provide(func(values map[string]int) string { return fmt.Sprint(len(values)) })
+		c.ResolveType(reflect.TypeOf(""))
+		return c
+	}
+
+	t.Run("Ignore keeps the cached values", func(t *testing.T) {
+		c := newContainer(LateIgnore)
+
+		err := c.ProvideKeyed("c", func() int { return 2 })
+		assert.NilError(t, err)
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "1")
+	})
+
+	t.Run("Error rejects the registration", func(t *testing.T) {
+		c := newContainer(LateError)
+
+		got := c.ProvideKeyed("c", func() int { return 2 })
+		assert.ErrorContains(t, got, "late registration of type map[string]int: map[string]int was already resolved")
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "1")
+	})
+
+	t.Run("Invalidate rebuilds the dependents", func(t *testing.T) {
+		c := newContainer(LateInvalidate)
+
+		err := c.ProvideKeyed("c", func() int { return 2 })
+		assert.NilError(t, err)
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "2")
+	})
+
+	t.Run("Invalidate on Decorate", func(t *testing.T) {
+		type Settings struct{ Name string }
+
+		c := New(OnLateProvide(LateInvalidate))
+		c.Provide(func() *Settings { return &Settings{Name: "default"} })
+		c.Provide(func(s *Settings) string { return s.Name })
+		c.ResolveType(reflect.TypeOf(""))
+
+		err := c.Decorate(func(s *Settings) *Settings { return &Settings{Name: s.Name + "+custom"} })
+		assert.NilError(t, err)
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "default+custom")
+	})
+
+	t.Run("Error on Provide", func(t *testing.T) {
+		c := New(OnLateProvide(LateError))
+		c.Provide(func() *strings.Builder { return &strings.Builder{} }, As(new(fmt.Stringer)))
+		c.Provide(func(s fmt.Stringer) string { return s.String() })
+		c.ResolveType(reflect.TypeOf(""))
+
+		got := c.Provide(func() *net.IPNet { return &net.IPNet{} }, As(new(fmt.Stringer)))
+		expected := errs.LateProvideError{TypeName: "fmt.Stringer", ResolvedName: "fmt.Stringer"}
+		assert.ErrorIs(t, got, expected)
+	})
+
+	t.Run("Unrelated registrations are accepted", func(t *testing.T) {
+		c := newContainer(LateError)
+
+		err := c.Provide(func() bool { return true })
+		assert.NilError(t, err)
+	})
+}
@@ -0,0 +1,553 @@
+package zeus
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+	"github.com/otoru/zeus/hooks"
+)
+
+// hookRecord keeps the hooks registered while building a type, so that they run with every Run needing the type.
+// Records of group members have a nil type, since they are not part of the type graph.
+// A record is settled once its hooks ran with a Run or Build, and is left to Close otherwise.
+// A record is evicted when CacheSize discarded its value before it was settled: it is dropped once settled.
+type hookRecord struct {
+	t        reflect.Type
+	stage    *hooks.LifecycleHooks
+	sequence uint64
+	settled  bool
+	evicted  bool
+}
+
+// everyRecord matches all the hook records, for Build and Close.
+func everyRecord(hookRecord) bool {
+	return true
+}
+
+// unsettled matches the hook records of values built outside of any Run or Build, such as with Resolve.
+func unsettled(record hookRecord) bool {
+	return !record.settled
+}
+
+// lifecycleContextType is the reflect.Type of LifecycleContext.
+var lifecycleContextType = reflect.TypeOf((*LifecycleContext)(nil)).Elem()
+
+// LifecycleContext is a context factories can depend on to tie the background goroutines of the value they build
+// to the container lifecycle. Each factory receives its own, canceled when the stop hooks of that value run,
+// before the stop hooks the factory registered itself, so that those can wait for the goroutines to return.
+//
+// Example:
+//
+//	c.Provide(func(ctx zeus.LifecycleContext) *Poller {
+//	    p := &Poller{}
+//	    go p.Loop(ctx)
+//	    return p
+//	})
+type LifecycleContext interface {
+	context.Context
+}
+
+// lifecycleContext returns a new LifecycleContext, canceled by a stop hook registered on the given stage.
+func lifecycleContext(stage *hooks.LifecycleHooks) reflect.Value {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stage.OnStop(func() error {
+		cancel()
+		return nil
+	})
+
+	return reflect.ValueOf(ctx)
+}
+
+// defaultGracePeriod bounds the stop hooks when the context given to RunContext is already done.
+const defaultGracePeriod = 5 * time.Second
+
+// Build constructs every type the container can build and runs the start hooks, without running any function.
+// It reports whether the whole graph can start: resolution errors are aggregated and returned before any hook runs.
+// On success the container is left started, so later calls to Run reuse it as-is and Close runs the stop hooks.
+// A container stopped by Close can be built again, which lets it resolve values anew.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(NewDatabase, NewServer)
+//	if err := c.Build(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer c.Close()
+func (c *Container) Build() error {
+	begun := time.Now()
+
+	c.mu.Lock()
+	c.closed = false
+	c.mu.Unlock()
+
+	errorSet := &errs.ErrorSet{}
+
+	for _, t := range c.Types() {
+		if err := c.detectCycle(t); err != nil {
+			errorSet.Add(err)
+			continue
+		}
+
+		if _, err := c.resolve(t, nil); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	if err := c.invokeLifecycles(); err != nil {
+		addFlattened(errorSet, err)
+	}
+
+	if !errorSet.IsEmpty() {
+		return errorSet.Result()
+	}
+
+	if c.isStarted() {
+		return nil
+	}
+
+	if err := c.start(c.collectHooks(everyRecord)); err != nil {
+		return err
+	}
+
+	recordStartup(c.startup, begun)
+	c.settle(everyRecord)
+
+	c.mu.Lock()
+	c.started = true
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Close runs the stop hooks of a container started with Build, in reverse construction order, and marks it as stopped.
+// From then on, resolutions fail with an errs.ContainerStoppedError, so that goroutines outliving the container
+// cannot build values that would never be cleaned up, until it is built or run again. Run marks the container
+// as stopped the same way once its stop hooks ran, unless another Run is still in progress.
+// If the container is not started, Close runs the stop hooks of the values built outside of any Run,
+// such as with Resolve or Extract, in reverse construction order. Each of those hooks runs once.
+//
+// Example:
+//
+//	c.Build()
+//	defer c.Close()
+func (c *Container) Close() error {
+	c.mu.Lock()
+	started := c.started
+	c.started = false
+	c.closed = true
+	c.mu.Unlock()
+
+	if !started {
+		pending := c.drain(unsettled)
+
+		if !pending.HasStopHooks() {
+			return nil
+		}
+
+		return c.stop(context.Background(), pending)
+	}
+
+	c.live.Add(-1)
+	return c.stop(context.Background(), c.drain(everyRecord))
+}
+
+// isStarted reports whether the container was started with Build and not closed yet.
+func (c *Container) isStarted() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.started
+}
+
+// isClosed reports whether the container was stopped by Close or at the end of a Run, and not built or run again.
+func (c *Container) isClosed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.closed
+}
+
+// OnReady registers a callback fired once the start hooks have all succeeded,
+// right before Run executes its function or Build returns.
+// It is not fired when a start hook fails.
+//
+// Example:
+//
+//	c.OnReady(func() { probe.MarkReady() })
+func (c *Container) OnReady(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ready = append(c.ready, fn)
+}
+
+// Ready reports whether the start hooks of the container completed and the stop hooks did not run since,
+// that is while the function given to Run executes, or between Build and Close. With several runs in progress,
+// including runs of overlays such as OverrideFor, it stays true until the last of them stops. It is safe for concurrent use,
+// so a health endpoint can reflect the lifecycle of the container.
+//
+// Example:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//	    if !c.Ready() {
+//	        w.WriteHeader(http.StatusServiceUnavailable)
+//	    }
+//	})
+func (c *Container) Ready() bool {
+	return c.live.Load() > 0
+}
+
+// start runs the given start hooks, emitting the matching events, then counts the container as live
+// and fires the ready callbacks on success.
+func (c *Container) start(h *hooks.LifecycleHooks) error {
+	c.emit(nil, PhaseStarting, nil)
+	err := h.Start()
+	c.emit(nil, PhaseStarted, err)
+
+	if err != nil {
+		return err
+	}
+
+	c.live.Add(1)
+
+	c.mu.RLock()
+	ready := slices.Clone(c.ready)
+	c.mu.RUnlock()
+
+	for _, fn := range ready {
+		fn()
+	}
+
+	return nil
+}
+
+// stop runs the given stop hooks with the given context, emitting the matching events.
+// A context that is already done is replaced by one bounded by the grace period.
+// Callers stopping the hooks of a successful start uncount it from the live ones first.
+func (c *Container) stop(ctx context.Context, h *hooks.LifecycleHooks) error {
+	if ctx.Err() != nil {
+		grace, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.grace)
+		defer cancel()
+		ctx = grace
+	}
+
+	c.emit(nil, PhaseStopping, nil)
+	err := h.StopContext(ctx)
+	c.emit(nil, PhaseStopped, err)
+
+	return err
+}
+
+// HookProviders returns the types whose construction registered at least one start or stop hook,
+// including cleanups given with WithCleanup, sorted by name. Hooks are only known once registered,
+// so types that have not been built yet are not reported: call it after Build to audit the whole graph.
+//
+// Example:
+//
+//	c.Build()
+//	for _, t := range c.HookProviders() {
+//	    fmt.Println(t, "manages a resource")
+//	}
+func (c *Container) HookProviders() []reflect.Type {
+	return c.hookTypes(everyRecord)
+}
+
+// hookTypes returns the types of the records matching the given function that registered a start or stop hook, sorted by name.
+func (c *Container) hookTypes(match func(hookRecord) bool) []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	types := []reflect.Type{}
+
+	for _, record := range c.records {
+		if record.t != nil && match(record) && (record.stage.HasStartHooks() || record.stage.HasStopHooks()) {
+			types = append(types, record.t)
+		}
+	}
+
+	return sortTypes(types)
+}
+
+// ResolveHooks is implemented by the Hooks given to factories, whose start hooks can take their parameters
+// from the container. Like RetryHooks, it is kept apart from Hooks so that existing implementations of Hooks
+// still satisfy it: check for it with a type assertion.
+type ResolveHooks interface {
+	OnStartResolve(fn interface{})
+}
+
+// containerHooks are the Hooks given to factories: the stage of their construction,
+// along with the container resolving the parameters of the hooks registered with OnStartResolve.
+type containerHooks struct {
+	*hooks.LifecycleHooks
+	container *Container
+}
+
+// OnStartResolve adds a function to be executed at the start, like OnStart, whose parameters are resolved
+// from the container when the hook runs. The function may return an error, failing the start like an OnStart hook.
+//
+// Example:
+//
+//	c.Provide(func(h zeus.Hooks) *Server {
+//	    h.(zeus.ResolveHooks).OnStartResolve(func(db *Database) error {
+//	        return db.Migrate()
+//	    })
+//	    return &Server{}
+//	})
+func (h containerHooks) OnStartResolve(fn interface{}) {
+	h.OnStart(func() error {
+		return h.container.invokeHook(fn)
+	})
+}
+
+// hooksFor returns the value given to a factory parameter of the given hooks type, collecting into the given stage.
+// Parameters of an interface type, such as Hooks, receive the stage wrapped with the container so that they implement
+// ResolveHooks too, while those of a concrete type receive the stage as-is.
+func (c *Container) hooksFor(t reflect.Type, stage *hooks.LifecycleHooks) reflect.Value {
+	if value := reflect.ValueOf(containerHooks{LifecycleHooks: stage, container: c}); value.Type().AssignableTo(t) {
+		return value
+	}
+
+	return reflect.ValueOf(stage)
+}
+
+// invokeHook calls a function registered with OnStartResolve after resolving its parameters.
+// Hooks the function registers itself are ignored, since the lifecycle they belong to already started.
+func (c *Container) invokeHook(fn interface{}) error {
+	if err := validateLifecycle(reflect.TypeOf(fn)); err != nil {
+		return err
+	}
+
+	return c.invokeLifecycle(reflect.ValueOf(fn), new(hooks.LifecycleHooks))
+}
+
+// validateLifecycle ensures that a function run for its effects returns at most an error.
+func validateLifecycle(fnType reflect.Type) error {
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return errs.NotAFunctionError{}
+	}
+
+	if numOut := fnType.NumOut(); numOut > 1 {
+		return errs.InvalidFactoryReturnError{NumReturns: numOut}
+	}
+
+	if fnType.NumOut() == 1 && fnType.Out(0) != errorType {
+		return errs.UnexpectedReturnTypeError{TypeName: fnType.Out(0).Name()}
+	}
+
+	return nil
+}
+
+// invokeLifecycle calls a function validated by validateLifecycle after resolving its parameters,
+// collecting the hooks it registers into the given stage, and returns the error it returns, if any.
+func (c *Container) invokeLifecycle(fn reflect.Value, stage *hooks.LifecycleHooks) error {
+	results, err := c.invokeAll(fn, nil, nil, stage)
+
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+
+	return nil
+}
+
+// ProvideLifecycle registers functions that only exist to register hooks, such as flushing a buffer created elsewhere,
+// without producing a resolvable type. Each function takes its parameters from the container, like a factory,
+// and returns at most an error. It is called by the next Run or Build, even though nothing depends on it,
+// and its hooks then run with every Run like those of group members. Prototype containers call it again for each run.
+// Returns an error if a function is not a function or returns something other than an error.
+//
+// Example:
+//
+//	c.ProvideLifecycle(func(h zeus.Hooks, buffer *Buffer) error {
+//	    h.OnStop(buffer.Flush)
+//	    return nil
+//	})
+func (c *Container) ProvideLifecycle(fns ...interface{}) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	for _, fn := range fns {
+		if err := validateLifecycle(reflect.TypeOf(fn)); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, fn := range fns {
+		c.lifecycles = append(c.lifecycles, lifecycle{fn: reflect.ValueOf(fn)})
+	}
+
+	return nil
+}
+
+// lifecycle is a function registered with ProvideLifecycle, along with whether it was called already.
+type lifecycle struct {
+	fn      reflect.Value
+	invoked bool
+}
+
+// invokeLifecycles calls the functions registered with ProvideLifecycle that were not called yet, or all of them
+// in prototype containers, and records their hooks. A function that fails is called again by the next lifecycle.
+func (c *Container) invokeLifecycles() error {
+	c.mu.Lock()
+	pending := []int{}
+	fns := []reflect.Value{}
+
+	for i := range c.lifecycles {
+		if !c.lifecycles[i].invoked || c.prototype {
+			c.lifecycles[i].invoked = true
+			pending = append(pending, i)
+			fns = append(fns, c.lifecycles[i].fn)
+		}
+	}
+
+	c.mu.Unlock()
+
+	errorSet := &errs.ErrorSet{}
+
+	for j, i := range pending {
+		stage := new(hooks.LifecycleHooks)
+
+		if err := c.invokeLifecycle(fns[j], stage); err != nil {
+			c.mu.Lock()
+			c.lifecycles[i].invoked = false
+			c.mu.Unlock()
+
+			errorSet.Add(err)
+			continue
+		}
+
+		c.record(nil, stage)
+	}
+
+	return errorSet.Result()
+}
+
+// record keeps the hooks registered while building the given type, in construction order.
+func (c *Container) record(t reflect.Type, stage *hooks.LifecycleHooks) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sequence++
+	c.records = append(c.records, hookRecord{t: t, stage: stage, sequence: c.sequence})
+}
+
+// mark returns the sequence number of the last hook record, so that the records kept later can be told apart.
+func (c *Container) mark() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sequence
+}
+
+// collectHooks gathers the hooks of the records matching the given function, in construction order.
+func (c *Container) collectHooks(match func(hookRecord) bool) *hooks.LifecycleHooks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	collected := new(hooks.LifecycleHooks)
+
+	for _, record := range c.records {
+		if match(record) {
+			collected.Append(record.stage)
+		}
+	}
+
+	return collected
+}
+
+// drain gathers the hooks of the records matching the given function in reverse construction order,
+// and settles those records so that they are not gathered again.
+func (c *Container) drain(match func(hookRecord) bool) *hooks.LifecycleHooks {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	collected := c.reversedHooks(match)
+
+	for i := range c.records {
+		if match(c.records[i]) {
+			c.records[i].settled = true
+		}
+	}
+
+	c.dropEvicted()
+
+	return collected
+}
+
+// stopHooks gathers the hooks of the records matching the given function in reverse construction order,
+// so that each value is stopped before the values it depends on.
+func (c *Container) stopHooks(match func(hookRecord) bool) *hooks.LifecycleHooks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.reversedHooks(match)
+}
+
+// reversedHooks gathers the hooks of the records matching the given function in reverse construction order.
+// The caller must hold the lock.
+func (c *Container) reversedHooks(match func(hookRecord) bool) *hooks.LifecycleHooks {
+	collected := new(hooks.LifecycleHooks)
+
+	for i := len(c.records) - 1; i >= 0; i-- {
+		if match(c.records[i]) {
+			collected.Append(c.records[i].stage)
+		}
+	}
+
+	return collected
+}
+
+// settle marks the hook records matching the given function as run by a lifecycle, so that Close leaves them alone.
+func (c *Container) settle(match func(hookRecord) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.records {
+		if match(c.records[i]) {
+			c.records[i].settled = true
+		}
+	}
+
+	c.dropEvicted()
+}
+
+// dropEvicted drops the records of evicted values once settled, since their hooks ran for the last time.
+// The caller must hold the lock for writing.
+func (c *Container) dropEvicted() {
+	c.records = slices.DeleteFunc(c.records, func(record hookRecord) bool {
+		return record.evicted && record.settled
+	})
+}
+
+// runRecords returns a function matching the hook records a Run needing the given types must run.
+// Singletons keep their hooks across runs, so they run with every Run needing them,
+// while prototype containers only run the hooks recorded since the given mark, by the values built for the run itself.
+func (c *Container) runRecords(needed map[reflect.Type]bool, mark uint64) func(hookRecord) bool {
+	return func(record hookRecord) bool {
+		if c.prototype && record.sequence <= mark {
+			return false
+		}
+
+		return record.t == nil || needed[record.t]
+	}
+}
+
+// forget drops the hook records matching the given function.
+func (c *Container) forget(match func(hookRecord) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.records = slices.DeleteFunc(c.records, match)
+}
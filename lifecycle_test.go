@@ -0,0 +1,732 @@
+package zeus
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+	"github.com/otoru/zeus/hooks"
+	"gotest.tools/v3/assert"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Build", func(t *testing.T) {
+		t.Run("Builds every provider and starts", func(t *testing.T) {
+			c := New()
+			started, stopped := 0, 0
+			built := map[string]bool{}
+
+			c.Provide(func(h Hooks) int {
+				built["int"] = true
+				h.OnStart(func() error { started++; return nil })
+				h.OnStop(func() error { stopped++; return nil })
+				return 42
+			})
+			c.Provide(func() string {
+				built["string"] = true
+				return "Hello"
+			})
+
+			err := c.Build()
+			assert.NilError(t, err)
+			assert.DeepEqual(t, built, map[string]bool{"int": true, "string": true})
+			assert.Equal(t, started, 1)
+
+			err = c.Run(func(i int) {})
+			assert.NilError(t, err)
+			assert.Equal(t, started, 1)
+			assert.Equal(t, stopped, 0)
+
+			err = c.Close()
+			assert.NilError(t, err)
+			assert.Equal(t, stopped, 1)
+
+			err = c.Close()
+			assert.NilError(t, err)
+			assert.Equal(t, stopped, 1)
+		})
+
+		t.Run("Reports failing factories", func(t *testing.T) {
+			c := New()
+			started := false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { started = true; return nil })
+				return 42
+			})
+			c.Provide(func(i int) (string, error) { return "", errors.New("string error") })
+			c.Provide(func() (bool, error) { return false, errors.New("bool error") })
+
+			err := c.Build()
+			assert.ErrorContains(t, err, "string error")
+			assert.ErrorContains(t, err, "bool error")
+			assert.Assert(t, !started)
+			assert.Assert(t, !c.isStarted())
+		})
+
+		t.Run("Reports failing start hooks", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { return errors.New("start error") })
+				return 42
+			})
+
+			err := c.Build()
+			assert.ErrorContains(t, err, "start error")
+			assert.Assert(t, !c.isStarted())
+		})
+
+		t.Run("Reports cycles", func(t *testing.T) {
+			c := New()
+			c.Provide(func(s string) int { return len(s) })
+			c.Provide(func(i int) string { return "" })
+
+			err := c.Build()
+			assert.ErrorContains(t, err, "cyclic dependency detected")
+		})
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		t.Run("Not started", func(t *testing.T) {
+			c := New()
+			stopped := 0
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { stopped++; return nil })
+				return 42
+			})
+
+			assert.NilError(t, c.Close())
+			assert.Equal(t, stopped, 0)
+
+			assert.NilError(t, c.Run(func(i int) {}))
+			assert.Equal(t, stopped, 1)
+
+			assert.NilError(t, c.Close())
+			assert.Equal(t, stopped, 1)
+		})
+
+		t.Run("Cleans up values resolved outside of a run in reverse order", func(t *testing.T) {
+			type Pool struct{}
+			type Repository struct{}
+
+			c := New()
+			var closed []string
+
+			c.Provide(func(h Hooks) *Pool {
+				h.OnStop(func() error { closed = append(closed, "pool"); return nil })
+				return &Pool{}
+			})
+			c.Provide(func(p *Pool, h Hooks) *Repository {
+				h.OnStop(func() error { closed = append(closed, "repository"); return nil })
+				return &Repository{}
+			})
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { closed = append(closed, "int"); return nil })
+				return 42
+			})
+
+			_, err := Resolve[*Repository](c)
+			assert.NilError(t, err)
+			_, err = c.ResolveType(reflect.TypeOf(0))
+			assert.NilError(t, err)
+
+			assert.NilError(t, c.Close())
+			assert.DeepEqual(t, closed, []string{"int", "repository", "pool"})
+
+			assert.NilError(t, c.Close())
+			assert.DeepEqual(t, closed, []string{"int", "repository", "pool"})
+		})
+
+		t.Run("Resolving after Close fails", func(t *testing.T) {
+			c := New()
+			built := 0
+			c.Provide(func() int { return 42 })
+			c.Provide(func() string { built++; return "Hello" })
+
+			assert.NilError(t, c.Build())
+			assert.NilError(t, c.Close())
+
+			_, err := Resolve[int](c)
+			assert.ErrorIs(t, err, errs.ContainerStoppedError{TypeName: "int"})
+
+			done := make(chan error)
+			go func() {
+				_, err := c.ResolveType(reflect.TypeOf(""))
+				done <- err
+			}()
+
+			assert.ErrorIs(t, <-done, errs.ContainerStoppedError{TypeName: "string"})
+			assert.Equal(t, built, 1)
+		})
+
+		t.Run("Resolving after Close without Build fails", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			assert.NilError(t, c.Close())
+
+			_, err := Resolve[int](c)
+			assert.ErrorIs(t, err, errs.ContainerStoppedError{TypeName: "int"})
+		})
+
+		t.Run("Resolving after a Run fails", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func() string { return "Hello" })
+
+			release, done := make(chan struct{}), make(chan error)
+			err := c.Run(func(i int) {
+				go func() {
+					<-release
+					_, err := Resolve[string](c)
+					done <- err
+				}()
+			})
+			assert.NilError(t, err)
+
+			close(release)
+			assert.ErrorIs(t, <-done, errs.ContainerStoppedError{TypeName: "string"})
+
+			assert.NilError(t, c.Run(func(s string) {}))
+		})
+
+		t.Run("Stop hooks run in reverse construction order", func(t *testing.T) {
+			type Pool struct{}
+			type Repository struct{}
+
+			var closed []string
+			newContainer := func() *Container {
+				c := New()
+				closed = nil
+				c.Provide(func(h Hooks) *Pool {
+					h.OnStop(func() error { closed = append(closed, "pool"); return nil })
+					return &Pool{}
+				})
+				c.Provide(func(p *Pool, h Hooks) *Repository {
+					h.OnStop(func() error { closed = append(closed, "repository"); return nil })
+					return &Repository{}
+				})
+				return c
+			}
+
+			c := newContainer()
+			assert.NilError(t, c.Run(func(*Repository) {}))
+			assert.DeepEqual(t, closed, []string{"repository", "pool"})
+
+			c = newContainer()
+			assert.NilError(t, c.Build())
+			assert.NilError(t, c.Close())
+			assert.DeepEqual(t, closed, []string{"repository", "pool"})
+		})
+
+		t.Run("Building again after Close", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			assert.NilError(t, c.Build())
+			assert.NilError(t, c.Close())
+			assert.NilError(t, c.Build())
+
+			got, err := Resolve[int](c)
+			assert.NilError(t, err)
+			assert.Equal(t, got, 42)
+		})
+	})
+
+	t.Run("HookProviders", func(t *testing.T) {
+		t.Run("Reports only the types registering hooks", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { return nil })
+				return 42
+			})
+			c.Provide(func(i int) string { return "Hello" })
+
+			assert.Equal(t, len(c.HookProviders()), 0)
+
+			assert.NilError(t, c.Build())
+			assert.DeepEqual(t, typeNames(c.HookProviders()), []string{"int"})
+		})
+
+		t.Run("Reports cleanups", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 }, WithCleanup(func(i int) {}))
+			c.Provide(func(h Hooks) string { return "Hello" })
+
+			_, err := c.ResolveType(reflect.TypeOf(""))
+			assert.NilError(t, err)
+			_, err = c.ResolveType(reflect.TypeOf(0))
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, typeNames(c.HookProviders()), []string{"int"})
+		})
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		t.Run("Hooks run once per run", func(t *testing.T) {
+			c := New()
+			started := 0
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { started++; return nil })
+				return 42
+			})
+
+			assert.NilError(t, c.Run(func(i int) {}))
+			assert.Equal(t, started, 1)
+
+			assert.NilError(t, c.Run(func(i int) {}))
+			assert.Equal(t, started, 2)
+		})
+
+		t.Run("Prototype hooks are not carried to later runs", func(t *testing.T) {
+			c := New(Prototype())
+			started, stopped := 0, 0
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { started++; return nil })
+				h.OnStop(func() error { stopped++; return nil })
+				return 42
+			})
+
+			assert.NilError(t, c.Run(func(i int) {}))
+			assert.NilError(t, c.Run(func(i int) {}))
+
+			assert.Equal(t, started, 2)
+			assert.Equal(t, stopped, 2)
+		})
+
+		t.Run("Hooks of types the run does not need are skipped", func(t *testing.T) {
+			c := New()
+			started := 0
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { started++; return nil })
+				return 42
+			})
+			c.Provide(func() string { return "Hello" })
+
+			assert.NilError(t, c.Run(func(i int) {}))
+			assert.NilError(t, c.Run(func(s string) {}))
+			assert.Equal(t, started, 1)
+		})
+
+		t.Run("Hooks of deferred types run on stop", func(t *testing.T) {
+			c := New()
+			stopped := 0
+			c.Provide(func(h Hooks) int {
+				h.OnStop(func() error { stopped++; return nil })
+				return 42
+			})
+
+			err := c.Run(func(p Provider[int]) error {
+				_, err := p()
+				return err
+			})
+
+			assert.NilError(t, err)
+			assert.Equal(t, stopped, 1)
+		})
+	})
+
+	t.Run("OnReady", func(t *testing.T) {
+		t.Run("Fires after the start hooks", func(t *testing.T) {
+			c := New()
+			var order []string
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { order = append(order, "start"); return nil })
+				return 42
+			})
+			c.OnReady(func() { order = append(order, "ready") })
+
+			err := c.Run(func(i int) { order = append(order, "run") })
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, order, []string{"start", "ready", "run"})
+		})
+
+		t.Run("Does not fire when a start hook fails", func(t *testing.T) {
+			c := New()
+			ready := false
+
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { return errors.New("start error") })
+				return 42
+			})
+			c.OnReady(func() { ready = true })
+
+			err := c.Run(func(i int) {})
+
+			assert.ErrorContains(t, err, "start error")
+			assert.Assert(t, !ready)
+		})
+	})
+
+	t.Run("RunContext", func(t *testing.T) {
+		type key struct{}
+
+		t.Run("Stop hooks receive the context", func(t *testing.T) {
+			c := New()
+			var got interface{}
+
+			c.Provide(func(h Hooks) int {
+				h.(ContextHooks).OnStopContext(func(ctx context.Context) error {
+					got = ctx.Value(key{})
+					return nil
+				})
+				return 42
+			})
+
+			ctx := context.WithValue(context.Background(), key{}, "value")
+			err := c.RunContext(ctx, func(i int) {})
+
+			assert.NilError(t, err)
+			assert.Equal(t, got, "value")
+		})
+
+		t.Run("Stop hooks get a grace period after cancellation", func(t *testing.T) {
+			c := New(StopGracePeriod(time.Second))
+			var stopCtx context.Context
+			var value interface{}
+			stopped := false
+
+			c.Provide(func(h Hooks) int {
+				h.(ContextHooks).OnStopContext(func(ctx context.Context) error {
+					stopCtx = ctx
+					value = ctx.Value(key{})
+					return ctx.Err()
+				})
+				h.OnStop(func() error {
+					stopped = true
+					return nil
+				})
+				return 42
+			})
+
+			ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+			err := c.RunContext(ctx, func(i int) { cancel() })
+
+			assert.NilError(t, err)
+			assert.Assert(t, stopped)
+			assert.Equal(t, value, "value")
+
+			deadline, ok := stopCtx.Deadline()
+			assert.Assert(t, ok)
+			assert.Assert(t, time.Until(deadline) <= time.Second)
+			assert.ErrorIs(t, stopCtx.Err(), context.Canceled)
+		})
+	})
+
+	t.Run("OnStartResolve", func(t *testing.T) {
+		type Database struct{ Migrated bool }
+		type Server struct{}
+
+		t.Run("Resolves the hook parameters at start", func(t *testing.T) {
+			c := New()
+			c.Provide(func() *Database { return &Database{} })
+			c.Provide(func(h Hooks) *Server {
+				h.(ResolveHooks).OnStartResolve(func(db *Database) error {
+					db.Migrated = true
+					return nil
+				})
+				return &Server{}
+			})
+
+			var migrated bool
+			err := c.Run(func(*Server) {
+				db, err := c.resolve(reflect.TypeOf(&Database{}), nil)
+				assert.NilError(t, err)
+				migrated = db.Interface().(*Database).Migrated
+			})
+			assert.NilError(t, err)
+			assert.Assert(t, migrated)
+		})
+
+		t.Run("Errors fail the start", func(t *testing.T) {
+			c := New()
+			ran := false
+			c.Provide(func(h Hooks) *Server {
+				h.(ResolveHooks).OnStartResolve(func(db *Database) {})
+				return &Server{}
+			})
+
+			err := c.Run(func(*Server) { ran = true })
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: ""})
+			assert.Assert(t, !ran)
+
+			c = New()
+			c.Provide(func(h Hooks) *Server {
+				h.(ResolveHooks).OnStartResolve(func() error { return errors.New("start error") })
+				return &Server{}
+			})
+
+			err = c.Run(func(*Server) {})
+			assert.Error(t, err, "start error")
+		})
+
+		t.Run("Is an optional interface", func(t *testing.T) {
+			var plain Hooks = new(hooks.LifecycleHooks)
+			_, ok := plain.(ResolveHooks)
+			assert.Assert(t, !ok)
+
+			c := New()
+			c.Provide(func(h Hooks) *Server {
+				_, resolves := h.(ResolveHooks)
+				_, retries := h.(RetryHooks)
+				_, contexts := h.(ContextHooks)
+				assert.Assert(t, resolves && retries && contexts)
+				return &Server{}
+			})
+
+			assert.NilError(t, c.Run(func(*Server) {}))
+		})
+
+		t.Run("Rejects invalid hooks at start", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) *Server {
+				h.(ResolveHooks).OnStartResolve(42)
+				return &Server{}
+			})
+
+			err := c.Run(func(*Server) {})
+			assert.ErrorIs(t, err, errs.NotAFunctionError{})
+		})
+
+		t.Run("Plain hooks parameters still receive the stage", func(t *testing.T) {
+			c := New()
+			started := false
+			c.Provide(func(h hooks.Hooks) *Server {
+				h.OnStart(func() error {
+					started = true
+					return nil
+				})
+				return &Server{}
+			})
+
+			assert.NilError(t, c.Run(func(*Server) {}))
+			assert.Assert(t, started)
+		})
+	})
+
+	t.Run("Ready", func(t *testing.T) {
+		t.Run("Follows a Run", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error {
+					assert.Assert(t, !c.Ready())
+					return nil
+				})
+				h.OnStop(func() error {
+					assert.Assert(t, !c.Ready())
+					return nil
+				})
+				return 0
+			})
+			assert.Assert(t, !c.Ready())
+
+			var during bool
+			err := c.Run(func(int) { during = c.Ready() })
+			assert.NilError(t, err)
+			assert.Assert(t, during)
+			assert.Assert(t, !c.Ready())
+		})
+
+		t.Run("Follows Build and Close", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 0 })
+
+			assert.NilError(t, c.Build())
+			assert.Assert(t, c.Ready())
+
+			assert.NilError(t, c.Close())
+			assert.Assert(t, !c.Ready())
+		})
+
+		t.Run("Stays true until the last concurrent Run stops", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 0 })
+
+			running := make(chan struct{})
+			release := make(chan struct{})
+			done := make(chan error)
+
+			go func() {
+				done <- c.Run(func(int) {
+					close(running)
+					<-release
+				})
+			}()
+			<-running
+
+			assert.NilError(t, c.Run(func(int) {}))
+			assert.Assert(t, c.Ready())
+
+			assert.NilError(t, c.Run(func(int) {}, OverrideFor(func() int { return 1 })))
+			assert.Assert(t, c.Ready())
+
+			close(release)
+			assert.NilError(t, <-done)
+			assert.Assert(t, !c.Ready())
+		})
+
+		t.Run("Stays false after a failed start", func(t *testing.T) {
+			c := New()
+			c.Provide(func(h Hooks) int {
+				h.OnStart(func() error { return errors.New("start error") })
+				return 0
+			})
+
+			assert.Error(t, c.Run(func(int) {}), "start error")
+			assert.Assert(t, !c.Ready())
+		})
+	})
+
+	t.Run("ProvideLifecycle", func(t *testing.T) {
+		t.Run("Hooks run with Run without any dependent", func(t *testing.T) {
+			c := New()
+			flushed := 0
+			c.Provide(func() *[]string { return &[]string{} })
+
+			err := c.ProvideLifecycle(func(h Hooks, buffer *[]string) error {
+				h.OnStop(func() error { flushed += len(*buffer); return nil })
+				return nil
+			})
+			assert.NilError(t, err)
+
+			err = c.Run(func(buffer *[]string) { *buffer = append(*buffer, "line") })
+			assert.NilError(t, err)
+			assert.Equal(t, flushed, 1)
+
+			err = c.Run(func() {})
+			assert.NilError(t, err)
+			assert.Equal(t, flushed, 2)
+		})
+
+		t.Run("Functions are called once", func(t *testing.T) {
+			c := New()
+			calls := 0
+			c.ProvideLifecycle(func() { calls++ })
+
+			assert.NilError(t, c.Run(func() {}))
+			assert.NilError(t, c.Run(func() {}))
+			assert.Equal(t, calls, 1)
+		})
+
+		t.Run("Functions are called again after ResetComputed", func(t *testing.T) {
+			c := New()
+			calls, started := 0, 0
+			c.ProvideLifecycle(func(h Hooks) {
+				calls++
+				h.OnStart(func() error { started++; return nil })
+			})
+
+			assert.NilError(t, c.Run(func() {}))
+			c.ResetComputed()
+			assert.NilError(t, c.Run(func() {}))
+			assert.Equal(t, calls, 2)
+			assert.Equal(t, started, 2)
+		})
+
+		t.Run("Hooks run with Build", func(t *testing.T) {
+			c := New()
+			started, stopped := false, false
+			c.ProvideLifecycle(func(h Hooks) {
+				h.OnStart(func() error { started = true; return nil })
+				h.OnStop(func() error { stopped = true; return nil })
+			})
+
+			assert.NilError(t, c.Build())
+			assert.Assert(t, started)
+			assert.NilError(t, c.Close())
+			assert.Assert(t, stopped)
+		})
+
+		t.Run("Errors fail the run and are retried", func(t *testing.T) {
+			c := New()
+			failure := errors.New("no buffer")
+			calls := 0
+			c.ProvideLifecycle(func() error {
+				calls++
+				if calls == 1 {
+					return failure
+				}
+				return nil
+			})
+
+			called := false
+			err := c.Run(func() { called = true })
+			assert.ErrorIs(t, err, failure)
+			assert.Assert(t, !called)
+
+			assert.NilError(t, c.Run(func() {}))
+			assert.Equal(t, calls, 2)
+		})
+
+		t.Run("Invalid functions are rejected", func(t *testing.T) {
+			c := New()
+
+			assert.ErrorIs(t, c.ProvideLifecycle(42), errs.NotAFunctionError{})
+			assert.ErrorIs(t, c.ProvideLifecycle(func() int { return 0 }), errs.UnexpectedReturnTypeError{TypeName: "int"})
+		})
+	})
+}
+
+func TestLifecycleContext(t *testing.T) {
+	t.Parallel()
+
+	type Poller struct {
+		ctx context.Context
+	}
+
+	t.Run("Canceled when the run stops", func(t *testing.T) {
+		c := New()
+		c.Provide(func(ctx LifecycleContext) *Poller { return &Poller{ctx: ctx} })
+
+		var poller *Poller
+		err := c.Run(func(p *Poller) {
+			poller = p
+			assert.NilError(t, p.ctx.Err())
+		})
+
+		assert.NilError(t, err)
+
+		select {
+		case <-poller.ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("lifecycle context was not canceled")
+		}
+	})
+
+	t.Run("Canceled before the stop hooks of the factory", func(t *testing.T) {
+		c := New()
+		var canceled bool
+
+		c.Provide(func(ctx LifecycleContext, h Hooks) *Poller {
+			h.OnStop(func() error {
+				canceled = ctx.Err() != nil
+				return nil
+			})
+
+			return &Poller{ctx: ctx}
+		})
+
+		assert.NilError(t, c.Run(func(p *Poller) {}))
+		assert.Assert(t, canceled)
+	})
+
+	t.Run("Each factory receives its own", func(t *testing.T) {
+		c := New()
+		c.Provide(func(ctx LifecycleContext) *Poller { return &Poller{ctx: ctx} })
+		c.Provide(func(ctx LifecycleContext) context.Context { return ctx })
+
+		got, err := Resolve[*Poller](c)
+		assert.NilError(t, err)
+
+		other, err := Resolve[context.Context](c)
+		assert.NilError(t, err)
+		assert.Assert(t, got.ctx != other)
+	})
+}
@@ -0,0 +1,75 @@
+package zeus
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	type Database struct{ Log *slog.Logger }
+	type Server struct{ Log *slog.Logger }
+
+	t.Run("Scoped per component", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		c := New(WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+
+		c.Provide(func(log *slog.Logger) *Database { return &Database{Log: log} })
+		c.Provide(func(log *slog.Logger, db *Database) *Server { return &Server{Log: log} })
+
+		err := c.Run(func(s *Server, db *Database) {
+			db.Log.Info("connected")
+			s.Log.Info("listening")
+		})
+		assert.NilError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assert.Equal(t, len(lines), 2)
+		assert.Assert(t, strings.Contains(lines[0], "msg=connected component=*zeus.Database"))
+		assert.Assert(t, strings.Contains(lines[1], "msg=listening component=*zeus.Server"))
+	})
+
+	t.Run("Provided logger takes precedence", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buffer, nil))
+		c := New()
+
+		c.Provide(func() *slog.Logger { return logger })
+		c.Provide(func(log *slog.Logger) *Database { return &Database{Log: log} })
+
+		err := c.Run(func(db *Database) {
+			assert.Equal(t, db.Log, logger)
+		})
+		assert.NilError(t, err)
+	})
+
+	t.Run("LogPlan logs the wiring plan before the function", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		c := New(LogPlan(), WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+
+		c.Provide(func(h Hooks) *Database {
+			h.OnStop(func() error { return nil })
+			return &Database{}
+		})
+		c.Provide(func(db *Database) *Server { return &Server{} })
+
+		err := c.Run(func(s *Server) {
+			assert.Assert(t, strings.Contains(buffer.String(), `msg="wiring plan" plan="[*zeus.Database *zeus.Server]" hooks=[*zeus.Database]`))
+		})
+		assert.NilError(t, err)
+	})
+
+	t.Run("Plan is not logged by default", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		c := New(WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+		c.Provide(func() *Database { return &Database{} })
+
+		assert.NilError(t, c.Run(func(*Database) {}))
+		assert.Equal(t, buffer.Len(), 0)
+	})
+}
@@ -0,0 +1,434 @@
+package zeus
+
+import (
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// Option configures a Container during its creation with New.
+type Option func(*Container)
+
+// Prototype disables the singleton cache of the container.
+// Every resolution builds a fresh value, which makes all providers transient:
+// a type shared by several dependents is constructed once per dependent.
+//
+// Keep in mind that factories run every time their type is requested,
+// so expensive constructors and hooks registered by them are repeated accordingly.
+//
+// Example:
+//
+//	c := zeus.New(zeus.Prototype())
+func Prototype() Option {
+	return func(c *Container) {
+		c.prototype = true
+	}
+}
+
+// Strict makes the container guard against factories returning a different type than the one their consumers request.
+// Registering a factory that returns an interface logs a warning, since the concrete type behind it cannot be requested,
+// and requesting a type that is only provided as an interface it implements, or with a different pointer-ness,
+// fails with an errs.ProvidedAsError naming the provided type instead of a DependencyResolutionError.
+//
+// Example:
+//
+//	c := zeus.New(zeus.Strict())
+func Strict() Option {
+	return func(c *Container) {
+		c.strict = true
+	}
+}
+
+// LogPlan makes Run log its wiring plan through the logger of the container, right before calling its function:
+// the types it constructs in construction order, as computed by Plan, and the types among them that registered hooks.
+// It gives operators a record of what was wired at startup.
+//
+// Example:
+//
+//	c := zeus.New(zeus.LogPlan())
+func LogPlan() Option {
+	return func(c *Container) {
+		c.logPlan = true
+	}
+}
+
+// CopyOnResolve makes the container hand out a shallow copy of a cached value each time it resolves a type
+// that is not a reference, such as a struct or an array, so consumers never share the storage of a singleton.
+// The factory still runs once. Pointers, interfaces, maps, slices, channels and functions are returned as cached.
+//
+// Example:
+//
+//	c := zeus.New(zeus.CopyOnResolve())
+func CopyOnResolve() Option {
+	return func(c *Container) {
+		c.copying = true
+	}
+}
+
+// RecordTimings makes each Run time its phases: the resolution of its parameters, the start hooks,
+// the function itself and the stop hooks. The timings of the last run are reported by LastRunTimings.
+// Without this option runs are not timed, to spare the overhead.
+//
+// Example:
+//
+//	c := zeus.New(zeus.RecordTimings())
+func RecordTimings() Option {
+	return func(c *Container) {
+		c.timings = new(timingLog)
+	}
+}
+
+// RecoverPanics makes the container recover panics raised by factories and by the functions given to Run.
+// A recovered panic is returned as an errs.PanicError naming the factory type or function,
+// and stop hooks still run when the panic comes from the function.
+// Without this option panics propagate to the caller.
+//
+// Example:
+//
+//	c := zeus.New(zeus.RecoverPanics())
+func RecoverPanics() Option {
+	return func(c *Container) {
+		c.recovering = true
+	}
+}
+
+// MaxConcurrency bounds the number of types Warm builds at the same time, and so the number of factories it runs concurrently.
+// A limit of zero or less, the default, builds every type given to Warm at once.
+//
+// Example:
+//
+//	c := zeus.New(zeus.MaxConcurrency(4))
+func MaxConcurrency(limit int) Option {
+	return func(c *Container) {
+		c.concurrency = limit
+	}
+}
+
+// MaxRunParams rejects the functions given to Run that take more than the given number of parameters,
+// with an errs.TooManyParametersError, as a guardrail keeping root functions small in large codebases.
+// A limit of zero or less, the default, accepts any number of parameters.
+//
+// Example:
+//
+//	c := zeus.New(zeus.MaxRunParams(5))
+func MaxRunParams(limit int) Option {
+	return func(c *Container) {
+		c.maxParams = limit
+	}
+}
+
+// RequireRunError rejects the functions given to Run that do not return an error, with an errs.MissingErrorReturnError,
+// so that every root function reports its failures. Without this option, functions may return nothing.
+//
+// Example:
+//
+//	c := zeus.New(zeus.RequireRunError())
+func RequireRunError() Option {
+	return func(c *Container) {
+		c.requireError = true
+	}
+}
+
+// CacheSize bounds the number of singleton values the container keeps, evicting the least recently resolved ones
+// to make room for new values. An evicted type is built again on its next resolution. Its stop hooks run on eviction
+// while the container is started with Build. Otherwise, hooks that are still to run, such as those of a value built
+// with Resolve or used by a Run in progress, are kept for the Run needing the type or for Close.
+// A size of zero or less, the default, keeps every value. The size does not apply to a store set with SetInstanceStore.
+//
+// Example:
+//
+//	c := zeus.New(zeus.CacheSize(128))
+func CacheSize(size int) Option {
+	return func(c *Container) {
+		c.capacity = size
+	}
+}
+
+// InterfaceFallback lets the container satisfy an interface nothing is bound to with the provided type implementing it,
+// such as a struct embedding the interface. Bindings declared with As still take precedence, and several implementations
+// make the interface ambiguous. Only interfaces fall back: a struct is never resolved from one embedding it.
+//
+// Example:
+//
+//	c := zeus.New(zeus.InterfaceFallback())
+//	c.Provide(func() *LoggingStore { return &LoggingStore{Store: base} })
+//	c.Run(func(s Store) { /* receives the *LoggingStore */ })
+func InterfaceFallback() Option {
+	return func(c *Container) {
+		c.fallback = true
+	}
+}
+
+// LatePolicy decides what happens when a type is registered after values depending on it were resolved and cached.
+type LatePolicy int
+
+const (
+	// LateIgnore keeps the cached values, which do not see the new registration. It is the default.
+	LateIgnore LatePolicy = iota
+	// LateError rejects the registration with an errs.LateProvideError.
+	LateError
+	// LateInvalidate drops the cached values depending on the registered type, so they are rebuilt with it.
+	// The hooks registered while building them are dropped without running.
+	LateInvalidate
+)
+
+// OnLateProvide sets the policy applied when Provide, Supply, ProvideKeyed, ProvideConverter or Decorate
+// register a type after values depending on it were resolved.
+//
+// Example:
+//
+//	c := zeus.New(zeus.OnLateProvide(zeus.LateInvalidate))
+func OnLateProvide(policy LatePolicy) Option {
+	return func(c *Container) {
+		c.late = policy
+	}
+}
+
+// AutoCollectInterfaces lets the container satisfy a slice of interfaces, such as []Plugin, that nothing provides
+// by collecting the value of every provided type implementing the interface, in registration order.
+// An empty slice is injected when no provided type implements it.
+//
+// Example:
+//
+//	c := zeus.New(zeus.AutoCollectInterfaces())
+//	c.Provide(NewAuthPlugin, NewMetricsPlugin)
+//	c.Run(func(plugins []Plugin) { /* both plugins */ })
+func AutoCollectInterfaces() Option {
+	return func(c *Container) {
+		c.collecting = true
+	}
+}
+
+// NilDefaults lets the container inject a nil value for the given interfaces when nothing provides them,
+// so that consumers can treat a missing implementation as a no-op. Each sample is a pointer to an interface,
+// e.g. (*Tracer)(nil); samples of other types are ignored. Any other unprovided interface still fails to resolve,
+// so real wiring mistakes are not hidden.
+//
+// Example:
+//
+//	c := zeus.New(zeus.NilDefaults((*Tracer)(nil)))
+//	c.Run(func(t Tracer) { /* t is nil unless a Tracer is provided */ })
+func NilDefaults(samples ...interface{}) Option {
+	return func(c *Container) {
+		if c.nilDefaults == nil {
+			c.nilDefaults = make(map[reflect.Type]bool)
+		}
+
+		for _, sample := range samples {
+			if t := sampleType(sample); t != nil && t.Kind() == reflect.Interface {
+				c.nilDefaults[t] = true
+			}
+		}
+	}
+}
+
+// StopGracePeriod sets how long stop hooks may take when the context given to RunContext is already done.
+// The hooks then receive a context that expires after the given duration. Defaults to five seconds.
+//
+// Example:
+//
+//	c := zeus.New(zeus.StopGracePeriod(10 * time.Second))
+func StopGracePeriod(d time.Duration) Option {
+	return func(c *Container) {
+		c.grace = d
+	}
+}
+
+// WithLogger sets the logger of the container, which defaults to slog.Default().
+// Factories depending on *slog.Logger receive it with a "component" attribute naming the type they build,
+// unless a factory for *slog.Logger is registered, in which case that one is injected as-is.
+//
+// Example:
+//
+//	c := zeus.New(zeus.WithLogger(slog.New(slog.NewJSONHandler(os.Stdout, nil))))
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Container) {
+		c.logger = logger
+	}
+}
+
+// ProvideOption configures the factories registered by a single Provide call.
+type ProvideOption func(*provideConfig)
+
+// provideConfig holds the settings gathered from the options of a Provide call.
+type provideConfig struct {
+	as       []reflect.Type
+	multi    bool
+	group    string
+	priority int
+	cleanups []interface{}
+	optional []reflect.Type
+	timeout  time.Duration
+	flag     string
+}
+
+// collectProvideOptions splits the arguments of Provide into its configuration and the actual factories.
+func collectProvideOptions(args []interface{}) (*provideConfig, []interface{}) {
+	config := new(provideConfig)
+	factories := []interface{}{}
+
+	for _, arg := range args {
+		if option, ok := arg.(ProvideOption); ok {
+			option(config)
+			continue
+		}
+
+		factories = append(factories, arg)
+	}
+
+	return config, factories
+}
+
+// As binds the return type of the factories to the given interfaces,
+// so that requesting one of those interfaces resolves the concrete type.
+// Interfaces are given as pointers, e.g. new(io.Reader).
+// When several concrete types are bound to the same interface, resolving it fails with an errs.AmbiguousDependencyError.
+// Registering the binding only checks that the concrete type implements the interfaces: its dependencies are resolved
+// when an interface is first requested, so they may be provided after the binding.
+//
+// Example:
+//
+//	c.Provide(NewFileStore, zeus.As(new(Store)))
+func As(interfaces ...interface{}) ProvideOption {
+	return func(config *provideConfig) {
+		for _, iface := range interfaces {
+			config.as = append(config.as, sampleType(iface))
+		}
+	}
+}
+
+// Group adds the factories to the named group instead of registering their return type.
+// Any number of factories may join a group, even with the same return type,
+// and the members are constructed together with ResolveGroup.
+//
+// Example:
+//
+//	c.Provide(NewAuthPlugin, NewMetricsPlugin, zeus.Group("plugins"))
+func Group(name string) ProvideOption {
+	return func(config *provideConfig) {
+		config.group = name
+	}
+}
+
+// Priority orders the factories within their group: ResolveGroup returns members with a higher priority first,
+// and members with the same priority in registration order. The default priority is zero.
+// Passed to Decorate, it orders the decorators of a type the same way, the first applied being the innermost.
+// It has no effect on other factories outside of a group.
+//
+// Example:
+//
+//	c.Provide(NewAuthMiddleware, zeus.Group("middleware"), zeus.Priority(10))
+func Priority(priority int) ProvideOption {
+	return func(config *provideConfig) {
+		config.priority = priority
+	}
+}
+
+// ConstructTimeout bounds how long the factories may take to build their values.
+// A factory exceeding the timeout is abandoned and the resolution fails with an errs.ConstructTimeoutError naming the type.
+// The abandoned call keeps running in the background, but its result and the hooks it registers are discarded,
+// so the type is built again on its next resolution. A timeout of zero or less, the default, does not bound factories.
+//
+// Example:
+//
+//	c.Provide(ConnectBroker, zeus.ConstructTimeout(5*time.Second))
+func ConstructTimeout(timeout time.Duration) ProvideOption {
+	return func(config *provideConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WhenFlag gates the factories behind a feature flag, read from the FeatureFlags registered in the container.
+// Each time a gated type is built, the flags are resolved and the factory is only used if the flag is enabled.
+// Otherwise the type is treated as unprovided, as if the factory returned errs.ErrSkip, so it can be built again
+// once the flag is turned on. A FeatureFlags factory depending on a gated type fails with a CyclicDependencyError.
+//
+// Example:
+//
+//	c.Provide(NewBetaSearch, zeus.WhenFlag("beta"))
+func WhenFlag(flag string) ProvideOption {
+	return func(config *provideConfig) {
+		config.flag = flag
+	}
+}
+
+// WithCleanup registers a destructor for the values built by the factories.
+// The cleanup takes the constructed value and may return an error. Once the value is built,
+// the cleanup is registered as a stop hook receiving that exact value.
+// Provide fails with an errs.InvalidCleanupError if the cleanup does not take a type returned by the factories.
+//
+// Example:
+//
+//	c.Provide(NewConn, zeus.WithCleanup(func(c *Conn) error { return c.Close() }))
+func WithCleanup(cleanup interface{}) ProvideOption {
+	return func(config *provideConfig) {
+		config.cleanups = append(config.cleanups, cleanup)
+	}
+}
+
+// RunOption configures a single call to Run.
+type RunOption func(*runConfig)
+
+// runConfig holds the settings gathered from the options of a Run call.
+type runConfig struct {
+	overrides []interface{}
+	strategy  errs.ResultStrategy
+	tagParams bool
+	dry       bool
+	started   func()
+	release   <-chan struct{}
+}
+
+// OverrideFor replaces the factories of the types returned by the given factories for a single Run.
+// Cached instances of those types, and of every type depending on them, are ignored during that run,
+// while the container itself keeps its factories and instances untouched.
+//
+// Example:
+//
+//	c.Run(handleRequest, zeus.OverrideFor(func() Clock { return fakeClock }))
+func OverrideFor(factories ...interface{}) RunOption {
+	return func(config *runConfig) {
+		config.overrides = append(config.overrides, factories...)
+	}
+}
+
+// ErrorStrategy sets what a single Run returns when several errors occur, such as failing dependencies and hooks.
+// By default they are returned together in an ErrorSet; errs.ResultFirst or errs.ResultLast return a single one instead.
+//
+// Example:
+//
+//	err := c.Run(cli, zeus.ErrorStrategy(errs.ResultFirst))
+func ErrorStrategy(strategy errs.ResultStrategy) RunOption {
+	return func(config *runConfig) {
+		config.strategy = strategy
+	}
+}
+
+// TagParameterErrors wraps each error a single Run fails to resolve a parameter with in an errs.ParameterError,
+// carrying the position and type of the parameter. The wrapped error is still matched by errors.Is and errors.As.
+// Without it, the resolution errors are returned as they are.
+//
+// Example:
+//
+//	err := c.Run(cli, zeus.TagParameterErrors())
+func TagParameterErrors() RunOption {
+	return func(config *runConfig) {
+		config.tagParams = true
+	}
+}
+
+// MultiReturn lets the factories return several related values, each registered as a provided type.
+// Without it, a second return value must be an error. With it, a factory may return any number of
+// distinct types, optionally followed by an error, and is called once for all of them.
+// Interfaces given to As must be implemented by the first returned type.
+//
+// Example:
+//
+//	c.Provide(func() (Host, Port) { return "localhost", 8080 }, zeus.MultiReturn())
+func MultiReturn() ProvideOption {
+	return func(config *provideConfig) {
+		config.multi = true
+	}
+}
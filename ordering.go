@@ -0,0 +1,49 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Before declares that the type of sample a must be constructed before the type of sample b,
+// even though b does not depend on a's value. Building b then builds a first,
+// so a's start hooks also run before b's. The samples are values of the types, or pointers to them for interfaces.
+// Returns a CyclicDependencyError, without recording the constraint, if it contradicts the existing ones or the type graph,
+// and an errs.FrozenError once the container is frozen.
+//
+// Example:
+//
+//	c.Provide(NewMigrator, NewSeeder)
+//	c.Before((*Migrator)(nil), (*Seeder)(nil))
+func (c *Container) Before(a, b interface{}) error {
+	if err := c.checkFrozen(); err != nil {
+		return err
+	}
+
+	first, then := sampleType(a), sampleType(b)
+
+	c.mu.Lock()
+	c.ordering[then] = append(c.ordering[then], first)
+	c.mu.Unlock()
+
+	if err := c.detectCycle(then); err != nil {
+		c.mu.Lock()
+		ordering := c.ordering[then]
+		if i := slices.Index(ordering, first); i >= 0 {
+			c.ordering[then] = slices.Delete(ordering, i, i+1)
+		}
+		c.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// predecessors returns the types that must be constructed before the given type, as declared with Before.
+func (c *Container) predecessors(t reflect.Type) []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.ordering[t])
+}
@@ -0,0 +1,67 @@
+package zeus
+
+import (
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestOrdering(t *testing.T) {
+	t.Parallel()
+
+	type Migrator struct{}
+	type Seeder struct{}
+
+	t.Run("Before", func(t *testing.T) {
+		t.Run("Constrains construction and start order", func(t *testing.T) {
+			c := New()
+			var built, started []string
+
+			c.Provide(func(h Hooks) *Seeder {
+				built = append(built, "seeder")
+				h.OnStart(func() error { started = append(started, "seeder"); return nil })
+				return &Seeder{}
+			})
+			c.Provide(func(h Hooks) *Migrator {
+				built = append(built, "migrator")
+				h.OnStart(func() error { started = append(started, "migrator"); return nil })
+				return &Migrator{}
+			})
+
+			err := c.Before((*Migrator)(nil), (*Seeder)(nil))
+			assert.NilError(t, err)
+
+			err = c.Run(func(s *Seeder) {})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, built, []string{"migrator", "seeder"})
+			assert.DeepEqual(t, started, []string{"migrator", "seeder"})
+		})
+
+		t.Run("Contradiction", func(t *testing.T) {
+			c := New()
+			c.Provide(func() *Migrator { return &Migrator{} })
+			c.Provide(func() *Seeder { return &Seeder{} })
+
+			err := c.Before((*Migrator)(nil), (*Seeder)(nil))
+			assert.NilError(t, err)
+
+			got := c.Before((*Seeder)(nil), (*Migrator)(nil))
+			assert.ErrorContains(t, got, "cyclic dependency detected")
+
+			err = c.Build()
+			assert.NilError(t, err)
+		})
+
+		t.Run("Contradicts the type graph", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func(i int) string { return "Hello" })
+
+			got := c.Before("", 0)
+			expected := errs.CyclicDependencyError{TypeName: "int"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+	})
+}
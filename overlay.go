@@ -0,0 +1,181 @@
+package zeus
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// RunScoped executes the provided function like Run, injecting the given values in place of the registered factories.
+// Each value is used for the type it is keyed by, and values depending on those types are rebuilt for just this call,
+// while the container itself keeps its factories and instances untouched. A nil value stands for the zero value of its type.
+// Returns an errs.MismatchedValueError if a value cannot be assigned to its type.
+//
+// Example:
+//
+//	c.RunScoped(map[reflect.Type]interface{}{
+//	    reflect.TypeOf((*Request)(nil)): req,
+//	}, handle)
+func (c *Container) RunScoped(values map[reflect.Type]interface{}, fn interface{}) error {
+	factories := make([]interface{}, 0, len(values))
+
+	for t, value := range values {
+		supplied := reflect.Zero(t)
+
+		if value != nil {
+			supplied = reflect.ValueOf(value)
+
+			if !supplied.Type().AssignableTo(t) {
+				return errs.MismatchedValueError{TypeName: t.String(), ValueType: supplied.Type().String()}
+			}
+
+			if supplied.Type() != t {
+				converted := reflect.New(t).Elem()
+				converted.Set(supplied)
+				supplied = converted
+			}
+		}
+
+		factoryType := reflect.FuncOf(nil, []reflect.Type{t}, false)
+		factory := reflect.MakeFunc(factoryType, func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{supplied}
+		})
+
+		factories = append(factories, factory.Interface())
+	}
+
+	return c.Run(fn, OverrideFor(factories...))
+}
+
+// overlay derives a temporary container where the given factories replace the registered ones.
+// Instances depending directly or transitively on a replaced type are left out, so they are rebuilt with the replacement.
+// Group members are always rebuilt, since they are not part of the type graph.
+// The derived container starts with the hooks recorded by the original one, keeps its own from then on,
+// and is meant to be discarded after use.
+func (c *Container) overlay(factories []interface{}) (*Container, error) {
+	overrides := make(map[reflect.Type]reflect.Value, len(factories))
+
+	for _, factory := range factories {
+		factoryType := reflect.TypeOf(factory)
+
+		if err := validateFactory(factoryType); err != nil {
+			return nil, err
+		}
+
+		overrides[factoryType.Out(0)] = reflect.ValueOf(factory)
+	}
+
+	replaced := make([]reflect.Type, 0, len(overrides))
+
+	for t := range overrides {
+		replaced = append(replaced, t)
+	}
+
+	affected := c.dependents(replaced)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	derived := &Container{
+		providers:           maps.Clone(c.providers),
+		registered:          slices.Clone(c.registered),
+		instances:           maps.Clone(c.instances),
+		keyed:               maps.Clone(c.keyed),
+		resolver:            c.resolver,
+		decorators:          maps.Clone(c.decorators),
+		decoratorPriorities: maps.Clone(c.decoratorPriorities),
+		bindings:            maps.Clone(c.bindings),
+		aliases:             maps.Clone(c.aliases),
+		converters:          maps.Clone(c.converters),
+		tuples:              make(map[reflect.Type][]reflect.Value),
+		ordering:            maps.Clone(c.ordering),
+		groups:              make(map[string][]*groupMember),
+		cleanups:            maps.Clone(c.cleanups),
+		optionals:           maps.Clone(c.optionals),
+		timeouts:            maps.Clone(c.timeouts),
+		flags:               maps.Clone(c.flags),
+		supplied:            maps.Clone(c.supplied),
+		disabled:            maps.Clone(c.disabled),
+		nilDefaults:         maps.Clone(c.nilDefaults),
+		building:            make(map[reflect.Type]*construction),
+		records:             slices.Clone(c.records),
+		lifecycles:          slices.Clone(c.lifecycles),
+		sequence:            c.sequence,
+		ready:               c.ready,
+		live:                c.live,
+		startup:             c.startup,
+		events:              c.events,
+		tracers:             slices.Clone(c.tracers),
+		warnings:            c.warnings,
+		timings:             c.timings,
+		started:             c.started,
+		closed:              c.closed,
+		grace:               c.grace,
+		concurrency:         c.concurrency,
+		capacity:            c.capacity,
+		maxParams:           c.maxParams,
+		recent:              slices.Clone(c.recent),
+		logger:              c.logger,
+		prototype:           c.prototype,
+		fallback:            c.fallback,
+		collecting:          c.collecting,
+		strict:              c.strict,
+		logPlan:             c.logPlan,
+		frozen:              c.frozen,
+		copying:             c.copying,
+		requireError:        c.requireError,
+		recovering:          c.recovering,
+	}
+
+	for group, members := range c.groups {
+		for _, member := range members {
+			derived.groups[group] = append(derived.groups[group], &groupMember{factory: member.factory, priority: member.priority})
+		}
+	}
+
+	maps.Copy(derived.providers, overrides)
+	maps.DeleteFunc(derived.optionals, func(t reflect.Type, _ []reflect.Type) bool {
+		_, replaced := overrides[t]
+		return replaced
+	})
+	maps.DeleteFunc(derived.instances, func(t reflect.Type, _ reflect.Value) bool {
+		return affected[t]
+	})
+
+	return derived, nil
+}
+
+// dependents returns the given types along with every cached type that depends on one of them, directly or not.
+func (c *Container) dependents(types []reflect.Type) map[reflect.Type]bool {
+	affected := make(map[reflect.Type]bool, len(types))
+
+	for _, t := range types {
+		affected[t] = true
+	}
+
+	c.mu.RLock()
+	cached := c.heldTypes()
+	c.mu.RUnlock()
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, t := range cached {
+			if affected[t] {
+				continue
+			}
+
+			for _, dependency := range c.dependenciesOf(t) {
+				if affected[dependency] {
+					affected[t] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return affected
+}
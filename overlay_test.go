@@ -0,0 +1,106 @@
+package zeus
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OverrideFor", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int) string { return fmt.Sprint("real ", i) })
+		c.Provide(func() bool { return true })
+
+		err := c.Run(func(s string, b bool) {
+			assert.Equal(t, s, "real 42")
+		})
+		assert.NilError(t, err)
+
+		err = c.Run(func(s string, i int) {
+			assert.Equal(t, s, "real 7")
+			assert.Equal(t, i, 7)
+		}, OverrideFor(func() int { return 7 }))
+		assert.NilError(t, err)
+
+		err = c.Run(func(s string, i int) {
+			assert.Equal(t, s, "real 42")
+			assert.Equal(t, i, 42)
+		})
+		assert.NilError(t, err)
+	})
+
+	t.Run("Unaffected instances are kept", func(t *testing.T) {
+		c := New()
+		calls := 0
+		c.Provide(func() int { return 42 })
+		c.Provide(func() bool { calls++; return true })
+
+		c.Run(func(b bool) {})
+		c.Run(func(b bool, i int) {}, OverrideFor(func() int { return 7 }))
+
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Invalid override", func(t *testing.T) {
+		c := New()
+		err := c.Run(func() {}, OverrideFor("not a function"))
+
+		assert.ErrorContains(t, err, "provided object is not a function")
+	})
+
+	t.Run("RunScoped", func(t *testing.T) {
+		type Request struct{ ID string }
+
+		t.Run("Injects the scoped values", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func(r *Request, i int) string { return fmt.Sprint(r.ID, " ", i) })
+
+			request := &Request{ID: "req-1"}
+			err := c.RunScoped(map[reflect.Type]interface{}{
+				reflect.TypeOf(request): request,
+				reflect.TypeOf(0):       7,
+			}, func(s string, r *Request) {
+				assert.Equal(t, s, "req-1 7")
+				assert.Equal(t, r, request)
+			})
+			assert.NilError(t, err)
+
+			_, cached := c.instances[reflect.TypeOf("")]
+			assert.Assert(t, !cached)
+			assert.Assert(t, !c.isProvided(reflect.TypeOf(request)))
+
+			err = c.Run(func(i int) { assert.Equal(t, i, 42) })
+			assert.NilError(t, err)
+		})
+
+		t.Run("Interface values", func(t *testing.T) {
+			c := New()
+			stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+			err := c.RunScoped(map[reflect.Type]interface{}{
+				stringerType: &strings.Builder{},
+			}, func(s fmt.Stringer) {
+				_, ok := s.(*strings.Builder)
+				assert.Assert(t, ok)
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Mismatched value", func(t *testing.T) {
+			c := New()
+			got := c.RunScoped(map[reflect.Type]interface{}{reflect.TypeOf(0): "seven"}, func(i int) {})
+			expected := errs.MismatchedValueError{TypeName: "int", ValueType: "string"}
+
+			assert.ErrorIs(t, got, expected)
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package zeus
+
+import (
+	"reflect"
+	"sync"
+)
+
+// injector is implemented by types whose values are built by the container itself
+// instead of being looked up among the registered factories.
+type injector interface {
+	inject(c *Container, stack *typeStack) reflect.Value
+	target() reflect.Type
+}
+
+// Provider is an injectable constructor for T.
+// Depending on Provider[T] instead of T defers the resolution of T until the provider is called.
+// Each call resolves T again, so singletons are returned as-is while prototype containers build fresh values.
+// Use Lazy[T] instead to keep the first value for the injection site.
+//
+// Example:
+//
+//	c.Provide(func(newConn zeus.Provider[*Conn]) *Pool {
+//	    return &Pool{factory: newConn}
+//	})
+type Provider[T any] func() (T, error)
+
+// target returns T, the type resolved on demand by the provider.
+func (Provider[T]) target() reflect.Type {
+	return typeOf[T]()
+}
+
+// inject builds a Provider bound to the container.
+// The stack being resolved is captured so that calling the provider while T is under construction reports a cycle.
+// The capture is dropped once the construction injecting the provider is over, so that later calls resolve from scratch.
+func (Provider[T]) inject(c *Container, stack *typeStack) reflect.Value {
+	t := typeOf[T]()
+	capture := stack.capture()
+
+	provider := Provider[T](func() (T, error) {
+		var zero T
+
+		value, err := c.resolve(t, capture.current())
+
+		if err != nil {
+			return zero, err
+		}
+
+		return value.Interface().(T), nil
+	})
+
+	return reflect.ValueOf(provider)
+}
+
+// Lazy is an injectable, memoized reference to T.
+// Unlike Provider[T], only the first successful call to Get resolves T: later calls return the same value,
+// even in prototype containers. The value is kept by the injection site, not by the container,
+// so each factory depending on Lazy[T] gets its own. Failed resolutions are not kept and are retried on the next call.
+//
+// Example:
+//
+//	c.Provide(func(conn zeus.Lazy[*Conn]) *Repository {
+//	    return &Repository{conn: conn}
+//	})
+type Lazy[T any] struct {
+	get func() (T, error)
+}
+
+// Get returns the value of T, resolving it on the first call.
+func (l Lazy[T]) Get() (T, error) {
+	return l.get()
+}
+
+// target returns T, the type resolved on demand by the lazy reference.
+func (Lazy[T]) target() reflect.Type {
+	return typeOf[T]()
+}
+
+// inject builds a Lazy bound to the container, resolving T like a Provider[T] would.
+func (Lazy[T]) inject(c *Container, stack *typeStack) reflect.Value {
+	provider := Provider[T](nil).inject(c, stack).Interface().(Provider[T])
+
+	var (
+		mu     sync.Mutex
+		value  T
+		cached bool
+	)
+
+	lazy := Lazy[T]{get: func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cached {
+			return value, nil
+		}
+
+		resolved, err := provider()
+
+		if err != nil {
+			return resolved, err
+		}
+
+		value, cached = resolved, true
+
+		return value, nil
+	}}
+
+	return reflect.ValueOf(lazy)
+}
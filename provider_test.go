@@ -0,0 +1,168 @@
+package zeus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+// parent and child depend on each other, the parent through a Provider to break the cycle.
+type parent struct{ child Provider[*child] }
+type child struct{ parent *parent }
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Injects a constructor", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+
+		err := c.Run(func(p Provider[int]) error {
+			value, err := p()
+			if err != nil {
+				return err
+			}
+
+			assert.Equal(t, value, 42)
+			return nil
+		})
+
+		assert.NilError(t, err)
+	})
+
+	t.Run("Singleton values are shared", func(t *testing.T) {
+		c := New()
+		calls := 0
+		c.Provide(func() int { calls++; return calls })
+
+		err := c.Run(func(p Provider[int]) {
+			first, _ := p()
+			second, _ := p()
+
+			assert.Equal(t, first, 1)
+			assert.Equal(t, second, 1)
+		})
+
+		assert.NilError(t, err)
+	})
+
+	t.Run("Transient values are distinct", func(t *testing.T) {
+		c := New(Prototype())
+		calls := 0
+		c.Provide(func() int { calls++; return calls })
+
+		err := c.Run(func(p Provider[int]) {
+			first, _ := p()
+			second, _ := p()
+
+			assert.Equal(t, first, 1)
+			assert.Equal(t, second, 2)
+		})
+
+		assert.NilError(t, err)
+	})
+
+	t.Run("Resolution errors are returned on call", func(t *testing.T) {
+		c := New()
+		c.Provide(func() (int, error) { return 0, errors.New("some error") })
+
+		err := c.Run(func(p Provider[int]) error {
+			_, err := p()
+			return err
+		})
+
+		assert.ErrorContains(t, err, "some error")
+	})
+
+	t.Run("Calling during construction reports a cycle", func(t *testing.T) {
+		c := New()
+		c.Provide(func(p Provider[string]) (string, error) {
+			return p()
+		})
+
+		err := c.Run(func(s string) {})
+
+		assert.ErrorIs(t, err, errs.CyclicDependencyError{TypeName: "string"})
+	})
+
+	t.Run("Calling after construction breaks a cycle", func(t *testing.T) {
+		for _, c := range []*Container{New(), New(Prototype())} {
+			c.Provide(func(p Provider[*child]) *parent { return &parent{child: p} })
+			c.Provide(func(p *parent) *child { return &child{parent: p} })
+
+			err := c.Run(func(parent *parent) error {
+				child, err := parent.child()
+				if err != nil {
+					return err
+				}
+
+				assert.Assert(t, child.parent != nil)
+				return nil
+			})
+
+			assert.NilError(t, err)
+		}
+	})
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Memoizes a transient value", func(t *testing.T) {
+		c := New(Prototype())
+		calls := 0
+		c.Provide(func() int { calls++; return calls })
+
+		err := c.Run(func(l Lazy[int]) {
+			first, _ := l.Get()
+			second, _ := l.Get()
+
+			assert.Equal(t, first, 1)
+			assert.Equal(t, second, 1)
+			assert.Equal(t, calls, 1)
+		})
+
+		assert.NilError(t, err)
+	})
+
+	t.Run("Defers the resolution", func(t *testing.T) {
+		c := New()
+		calls := 0
+		c.Provide(func() int { calls++; return calls })
+
+		err := c.Run(func(l Lazy[int]) {
+			assert.Equal(t, calls, 0)
+
+			value, err := l.Get()
+			assert.NilError(t, err)
+			assert.Equal(t, value, 1)
+		})
+
+		assert.NilError(t, err)
+	})
+
+	t.Run("Errors are not memoized", func(t *testing.T) {
+		c := New(Prototype())
+		calls := 0
+		c.Provide(func() (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, errors.New("some error")
+			}
+			return calls, nil
+		})
+
+		err := c.Run(func(l Lazy[int]) {
+			_, err := l.Get()
+			assert.ErrorContains(t, err, "some error")
+
+			value, err := l.Get()
+			assert.NilError(t, err)
+			assert.Equal(t, value, 2)
+		})
+
+		assert.NilError(t, err)
+	})
+}
@@ -0,0 +1,104 @@
+package zeus
+
+import (
+	"reflect"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// Registration describes a factory to register with Register, along with its metadata.
+// A Name registers the factory like ProvideKeyed, and a Group like Provide with the Group option;
+// a registration cannot have both. Eager registrations are built right after the whole batch is registered,
+// so they may depend on registrations appearing later in the batch. An eager group member builds just itself.
+type Registration struct {
+	Factory interface{}
+	Name    string
+	Group   string
+	Eager   bool
+}
+
+// Register registers every factory described by the given registrations, in order.
+// Registrations that fail do not stop the others: their errors are aggregated and returned together,
+// followed by the errors of the eager registrations that failed to build.
+//
+// Example:
+//
+//	err := c.Register(
+//	    zeus.Registration{Factory: NewDatabase, Eager: true},
+//	    zeus.Registration{Factory: NewPrimaryEndpoint, Name: "primary"},
+//	    zeus.Registration{Factory: NewAuthPlugin, Group: "plugins"},
+//	)
+func (c *Container) Register(registrations ...Registration) error {
+	errorSet := &errs.ErrorSet{}
+	eager := []func() error{}
+
+	for _, registration := range registrations {
+		build, err := c.register(registration)
+
+		if err != nil {
+			errorSet.Add(err)
+			continue
+		}
+
+		if registration.Eager {
+			eager = append(eager, build)
+		}
+	}
+
+	for _, build := range eager {
+		if err := build(); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	return errorSet.Result()
+}
+
+// register registers a single factory, and returns the function building what it provides for eager registrations.
+func (c *Container) register(registration Registration) (func() error, error) {
+	factoryType := reflect.TypeOf(registration.Factory)
+
+	switch {
+	case registration.Name != "" && registration.Group != "":
+		return nil, errs.ConflictingRegistrationError{Name: registration.Name, Group: registration.Group}
+	case registration.Name != "":
+		if err := c.ProvideKeyed(registration.Name, registration.Factory); err != nil {
+			return nil, err
+		}
+
+		keyedType := reflect.MapOf(reflect.TypeOf(""), factoryType.Out(0))
+
+		return func() error {
+			_, err := c.resolve(keyedType, nil)
+			return err
+		}, nil
+	case registration.Group != "":
+		if err := c.Provide(registration.Factory, Group(registration.Group)); err != nil {
+			return nil, err
+		}
+
+		c.mu.RLock()
+		members := c.groups[registration.Group]
+		member := members[len(members)-1]
+		c.mu.RUnlock()
+
+		return func() error {
+			_, err := c.buildMember(member)
+			return err
+		}, nil
+	}
+
+	if err := c.Provide(registration.Factory); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		for _, t := range factoryOutputs(factoryType) {
+			if _, err := c.resolve(t, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
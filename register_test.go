@@ -0,0 +1,80 @@
+package zeus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type Config struct{ Addr string }
+	type Database struct{ Config Config }
+
+	t.Run("Registers a mixed batch", func(t *testing.T) {
+		c := New()
+		built := []string{}
+
+		err := c.Register(
+			Registration{Factory: func(config Config) *Database {
+				built = append(built, "database")
+				return &Database{Config: config}
+			}, Eager: true},
+			Registration{Factory: func() Config { return Config{Addr: ":5432"} }},
+			Registration{Factory: func() string { return "db-1" }, Name: "primary"},
+			Registration{Factory: func() string {
+				built = append(built, "replica")
+				return "db-2"
+			}, Name: "replica", Eager: true},
+			Registration{Factory: func() int {
+				built = append(built, "plugin")
+				return 1
+			}, Group: "plugins", Eager: true},
+			Registration{Factory: func() int { return 2 }, Group: "plugins"},
+		)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, built, []string{"database", "replica", "plugin"})
+
+		c.mu.RLock()
+		_, cached := c.instances[reflect.TypeOf(&Database{})]
+		c.mu.RUnlock()
+		assert.Assert(t, cached)
+
+		err = c.Run(func(db *Database, endpoints map[string]string) {
+			assert.Equal(t, db.Config.Addr, ":5432")
+			assert.DeepEqual(t, endpoints, map[string]string{"primary": "db-1", "replica": "db-2"})
+		})
+		assert.NilError(t, err)
+
+		plugins, err := c.ResolveGroup("plugins", 0)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, plugins, []interface{}{1, 2})
+		assert.DeepEqual(t, built, []string{"database", "replica", "plugin"})
+	})
+
+	t.Run("Aggregates errors across registrations", func(t *testing.T) {
+		c := New()
+		failure := errors.New("database unavailable")
+
+		err := c.Register(
+			Registration{Factory: 42},
+			Registration{Factory: func() (*Database, error) { return nil, failure }, Eager: true},
+			Registration{Factory: func() string { return "" }, Name: "primary", Group: "endpoints"},
+			Registration{Factory: func() Config { return Config{} }},
+		)
+
+		var errorSet *errs.ErrorSet
+		assert.Assert(t, errors.As(err, &errorSet))
+		assert.Equal(t, len(errorSet.Errors()), 3)
+		assert.ErrorIs(t, errorSet.Join(), errs.NotAFunctionError{})
+		assert.ErrorIs(t, errorSet.Join(), errs.ConflictingRegistrationError{Name: "primary", Group: "endpoints"})
+		assert.ErrorIs(t, errorSet.Join(), failure)
+
+		_, err = c.resolve(reflect.TypeOf(Config{}), nil)
+		assert.NilError(t, err)
+	})
+}
@@ -0,0 +1,102 @@
+package zeus
+
+import (
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Registry describes the wiring of a container.
+// Factories can depend on Registry to inspect the container that builds them, like they do with Hooks.
+//
+// Example:
+//
+//	c.Provide(func(r zeus.Registry) *DiagnosticsHandler {
+//	    return &DiagnosticsHandler{types: r.Types()}
+//	})
+type Registry interface {
+	Types() []reflect.Type
+}
+
+// Types returns every type the container can build from its factories, converters and aliases, sorted by name.
+// Keyed factories are reported through the map type they are aggregated into.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Provide(func() int { return 42 })
+//	fmt.Println(c.Types()) // Outputs: [int]
+func (c *Container) Types() []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	types := make([]reflect.Type, 0, len(c.providers)+len(c.keyed))
+
+	for t := range c.providers {
+		types = append(types, t)
+	}
+
+	for t := range c.keyed {
+		mapType := reflect.MapOf(reflect.TypeOf(""), t)
+
+		if _, exists := c.providers[mapType]; !exists {
+			types = append(types, mapType)
+		}
+	}
+
+	for t := range c.converters {
+		if _, exists := c.providers[t]; !exists {
+			types = append(types, t)
+		}
+	}
+
+	for t := range c.aliases {
+		_, exists := c.providers[t]
+
+		if _, converted := c.converters[t]; !exists && !converted {
+			types = append(types, t)
+		}
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	return types
+}
+
+// closerType is the reflect.Type of io.Closer.
+var closerType = reflect.TypeOf((*io.Closer)(nil)).Elem()
+
+// NonClosableProviders returns the provided types whose values cannot be closed, sorted by name:
+// neither the type nor a pointer to it implements io.Closer, and no cleanup was given for it with WithCleanup.
+// It helps spotting resources that will never be released, such as a connection returned as a type without Close.
+// Values registered with Supply are left out, since their owner releases them.
+// Interfaces are judged by their methods, not by the values their factories return.
+//
+// Example:
+//
+//	for _, t := range c.NonClosableProviders() {
+//	    log.Printf("%s is never closed", t)
+//	}
+func (c *Container) NonClosableProviders() []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	types := []reflect.Type{}
+
+	for _, t := range c.registered {
+		if isCloser(t) || c.supplied[t] || len(c.cleanups[t]) > 0 {
+			continue
+		}
+
+		types = append(types, t)
+	}
+
+	return sortTypes(types)
+}
+
+// isCloser reports whether the values of the given type can be closed, directly or through their address.
+func isCloser(t reflect.Type) bool {
+	return t.Implements(closerType) || t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(closerType)
+}
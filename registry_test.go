@@ -0,0 +1,76 @@
+package zeus
+
+import (
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// addressCloser implements io.Closer through its address only.
+type addressCloser struct{}
+
+func (*addressCloser) Close() error { return nil }
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Types", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int) string { return "Hello" })
+		c.ProvideKeyed("primary", func() float64 { return 1 })
+
+		expected := []reflect.Type{
+			reflect.TypeOf(0),
+			reflect.TypeOf(map[string]float64{}),
+			reflect.TypeOf(""),
+		}
+
+		assert.Assert(t, slices.Equal(c.Types(), expected))
+	})
+
+	t.Run("NonClosableProviders", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func() io.ReadCloser { return io.NopCloser(nil) })
+		c.Provide(func() *os.File { return nil }, func() string { return "Hello" })
+		c.Provide(func() *net.IPNet { return &net.IPNet{} }, WithCleanup(func(n *net.IPNet) {}))
+		c.Provide(func() addressCloser { return addressCloser{} })
+		c.Supply(3.14)
+
+		expected := []reflect.Type{
+			reflect.TypeOf(0),
+			reflect.TypeOf(""),
+		}
+
+		assert.Assert(t, slices.Equal(c.NonClosableProviders(), expected))
+	})
+
+	t.Run("Registry injection", func(t *testing.T) {
+		c := New()
+
+		type Diagnostics struct {
+			Types []reflect.Type
+		}
+
+		c.Provide(func() int { return 42 })
+		c.Provide(func(r Registry) *Diagnostics {
+			return &Diagnostics{Types: r.Types()}
+		})
+
+		var got []reflect.Type
+		err := c.Run(func(d *Diagnostics) {
+			got = d.Types
+		})
+
+		assert.NilError(t, err)
+		assert.Assert(t, len(got) == 2)
+		assert.Equal(t, got[0], reflect.TypeOf(&Diagnostics{}))
+		assert.Equal(t, got[1], reflect.TypeOf(0))
+	})
+}
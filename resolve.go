@@ -0,0 +1,187 @@
+package zeus
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// typeOf returns the type T stands for. Going through a pointer keeps interface types intact,
+// where reflect.TypeOf on a zero T would return nil, or the dynamic type of a value.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Resolve returns the value of type T, constructing it and its dependencies if needed.
+// Resolving a type whose start hooks have not run, because the container is neither started with Build
+// nor running a function, logs a warning: those hooks only run with the next Run needing the type.
+// Stop hooks registered while building values outside of a Run are run by Close.
+//
+// Example:
+//
+//	db, err := zeus.Resolve[*Database](c)
+func Resolve[T any](c *Container) (T, error) {
+	var zero T
+
+	value, err := c.resolveOutside(typeOf[T]())
+
+	if err != nil {
+		return zero, err
+	}
+
+	result, _ := value.Interface().(T)
+	return result, nil
+}
+
+// OrElse resolves T like Resolve, or returns the result of the given default if T is not provided,
+// which suits optional integrations. Only an errs.DependencyResolutionError naming T itself falls back to the default,
+// including when the factory of T returns errs.ErrSkip: other errors, such as a CyclicDependencyError
+// or a missing dependency of T, are returned as-is.
+//
+// Example:
+//
+//	tracer, err := zeus.OrElse(c, func() Tracer { return noopTracer{} })
+func OrElse[T any](c *Container, fallback func() T) (T, error) {
+	value, err := Resolve[T](c)
+	t := typeOf[T]()
+
+	var missing errs.DependencyResolutionError
+
+	if !errors.As(err, &missing) || missing.TypeName != t.Name() {
+		return value, err
+	}
+
+	// Unnamed types, such as pointers, share their empty name with their unnamed dependencies,
+	// so the error may still come from a dependency of T: only fall back if those can be built.
+	if t.Name() == "" && len(c.functionIssues(reflect.FuncOf(c.dependenciesOf(t), nil, false))) > 0 {
+		return value, err
+	}
+
+	return fallback(), nil
+}
+
+// Extract resolves the type pointed to by target and stores the value in it, like Resolve.
+// Returns an errs.NotAPointerError if target is not a non-nil pointer.
+//
+// Example:
+//
+//	var db *Database
+//	err := c.Extract(&db)
+func (c *Container) Extract(target interface{}) error {
+	pointer := reflect.ValueOf(target)
+
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		return errs.NotAPointerError{}
+	}
+
+	value, err := c.resolveOutside(pointer.Type().Elem())
+
+	if err != nil {
+		return err
+	}
+
+	pointer.Elem().Set(value)
+
+	return nil
+}
+
+// resolveOutside resolves the given type on behalf of Resolve and Extract,
+// warning when start hooks needed by the type have not run.
+func (c *Container) resolveOutside(t reflect.Type) (reflect.Value, error) {
+	value, err := c.resolve(t, nil)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if c.isStarted() || c.running.Load() > 0 {
+		return value, nil
+	}
+
+	if c.collectHooks(c.runRecords(c.reachable([]reflect.Type{t}), 0)).HasStartHooks() {
+		c.logger.Warn("resolved outside of a lifecycle, its start hooks have not run", "type", t.String())
+	}
+
+	return value, nil
+}
+
+// ResolveByName resolves the provided type whose name is the given string, like Resolve, for callers that only know
+// types by name, such as a scripting layer. The name is matched, in order, against the fully-qualified name of the types,
+// which includes their import path as in "*github.com/acme/db.Pool", their reflect.Type string, as in "*db.Pool",
+// and their short name without package, as in "*Pool".
+// Returns an errs.AmbiguousDependencyError listing the candidates if several types match at the same level,
+// in which case a more qualified name is needed, or an errs.DependencyResolutionError if none does.
+//
+// Example:
+//
+//	pool, err := c.ResolveByName("*db.Pool")
+func (c *Container) ResolveByName(typeName string) (interface{}, error) {
+	t, err := c.typeNamed(typeName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := c.resolveOutside(t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.Interface(), nil
+}
+
+// typeNamed returns the provided type matching the given name, as described by ResolveByName.
+func (c *Container) typeNamed(typeName string) (reflect.Type, error) {
+	c.mu.RLock()
+	provided := make([]reflect.Type, 0, len(c.providers))
+
+	for t := range c.providers {
+		provided = append(provided, t)
+	}
+
+	c.mu.RUnlock()
+
+	for _, name := range []func(reflect.Type) string{qualifiedName, reflect.Type.String, shortName} {
+		matches := slices.DeleteFunc(slices.Clone(provided), func(t reflect.Type) bool {
+			return name(t) != typeName
+		})
+
+		switch {
+		case len(matches) == 1:
+			return matches[0], nil
+		case len(matches) > 1:
+			return nil, errs.AmbiguousDependencyError{TypeName: typeName, Candidates: typeNames(sortTypes(matches))}
+		}
+	}
+
+	return nil, errs.DependencyResolutionError{TypeName: typeName}
+}
+
+// qualifiedName returns the name of the given type qualified by the import path of its package, if it has one.
+func qualifiedName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer && t.Name() == "" {
+		return "*" + qualifiedName(t.Elem())
+	}
+
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	return t.String()
+}
+
+// shortName returns the name of the given type without its package.
+func shortName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer && t.Name() == "" {
+		return "*" + shortName(t.Elem())
+	}
+
+	if t.Name() != "" {
+		return t.Name()
+	}
+
+	return t.String()
+}
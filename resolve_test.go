@@ -0,0 +1,319 @@
+package zeus
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	type Database struct{ Name string }
+
+	newContainer := func(buffer *bytes.Buffer) *Container {
+		c := New(WithLogger(slog.New(slog.NewTextHandler(buffer, nil))))
+		c.Provide(func(h Hooks) *Database {
+			h.OnStart(func() error { return nil })
+			return &Database{Name: "main"}
+		})
+		c.Provide(func(db *Database) string { return db.Name })
+		c.Provide(func() int { return 42 })
+		return c
+	}
+
+	t.Run("Resolve", func(t *testing.T) {
+		t.Run("Returns the typed value", func(t *testing.T) {
+			c := newContainer(&bytes.Buffer{})
+
+			value, err := Resolve[int](c)
+			assert.NilError(t, err)
+			assert.Equal(t, value, 42)
+		})
+
+		t.Run("Resolves interface type parameters", func(t *testing.T) {
+			c := New()
+			buffer := bytes.NewBufferString("payload")
+			c.Provide(func() io.Reader { return buffer })
+
+			reader, err := Resolve[io.Reader](c)
+			assert.NilError(t, err)
+			assert.Equal(t, reader, io.Reader(buffer))
+
+			_, err = Resolve[io.Writer](c)
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "Writer"})
+		})
+
+		t.Run("Resolves a nil interface value", func(t *testing.T) {
+			c := New()
+			c.Provide(func() io.Reader { return nil })
+
+			reader, err := Resolve[io.Reader](c)
+			assert.NilError(t, err)
+			assert.Assert(t, reader == nil)
+		})
+
+		t.Run("Resolves concrete pointer type parameters", func(t *testing.T) {
+			c := New()
+			buffer := &bytes.Buffer{}
+			c.Provide(func() *bytes.Buffer { return buffer })
+
+			got, err := Resolve[*bytes.Buffer](c)
+			assert.NilError(t, err)
+			assert.Equal(t, got, buffer)
+
+			_, err = Resolve[io.Reader](c)
+			assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "Reader"})
+		})
+
+		t.Run("Returns resolution errors", func(t *testing.T) {
+			c := New()
+			c.Provide(func() (int, error) { return 0, errors.New("some error") })
+
+			_, err := Resolve[int](c)
+			assert.ErrorContains(t, err, "some error")
+		})
+
+		t.Run("Warns about start hooks that have not run", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := newContainer(buffer)
+
+			_, err := Resolve[string](c)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(buffer.String(), "level=WARN"))
+			assert.Assert(t, strings.Contains(buffer.String(), "type=string"))
+		})
+
+		t.Run("Does not warn without start hooks", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := newContainer(buffer)
+
+			_, err := Resolve[int](c)
+			assert.NilError(t, err)
+			assert.Equal(t, buffer.String(), "")
+		})
+
+		t.Run("Does not warn within a lifecycle", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := newContainer(buffer)
+
+			assert.NilError(t, c.Build())
+			defer c.Close()
+
+			_, err := Resolve[*Database](c)
+			assert.NilError(t, err)
+			assert.Equal(t, buffer.String(), "")
+		})
+
+		t.Run("Does not warn within a run with overrides", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := newContainer(buffer)
+
+			err := c.Run(func(int) {
+				_, err := Resolve[*Database](c)
+				assert.NilError(t, err)
+			}, OverrideFor(func() int { return 7 }))
+			assert.NilError(t, err)
+			assert.Equal(t, buffer.String(), "")
+		})
+	})
+
+	t.Run("Extract", func(t *testing.T) {
+		t.Run("Fills the target", func(t *testing.T) {
+			c := newContainer(&bytes.Buffer{})
+
+			var value int
+			err := c.Extract(&value)
+
+			assert.NilError(t, err)
+			assert.Equal(t, value, 42)
+		})
+
+		t.Run("Warns about start hooks that have not run", func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			c := newContainer(buffer)
+
+			var db *Database
+			err := c.Extract(&db)
+
+			assert.NilError(t, err)
+			assert.Equal(t, db.Name, "main")
+			assert.Assert(t, strings.Contains(buffer.String(), "level=WARN"))
+		})
+
+		t.Run("Not a pointer", func(t *testing.T) {
+			c := newContainer(&bytes.Buffer{})
+
+			var value int
+			got := c.Extract(value)
+
+			assert.ErrorIs(t, got, errs.NotAPointerError{})
+		})
+	})
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, typeOf[io.Reader]().String(), "io.Reader")
+	assert.Equal(t, typeOf[*bytes.Buffer]().String(), "*bytes.Buffer")
+	assert.Equal(t, typeOf[error](), errorType)
+	assert.Equal(t, typeOf[interface{}]().Kind(), reflect.Interface)
+}
+
+func TestOrElse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Provided types are resolved", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 })
+
+		n, err := OrElse(c, func() int { return 0 })
+		assert.NilError(t, err)
+		assert.Equal(t, n, 42)
+	})
+
+	t.Run("Unprovided types use the default", func(t *testing.T) {
+		c := New()
+
+		reader, err := OrElse(c, func() io.Reader { return strings.NewReader("default") })
+		assert.NilError(t, err)
+		assert.Equal(t, reader.(*strings.Reader).Len(), len("default"))
+	})
+
+	t.Run("Missing dependencies are returned", func(t *testing.T) {
+		c := New()
+		c.Provide(func(n int) string { return "" })
+
+		_, err := OrElse(c, func() string { return "default" })
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "int"})
+	})
+
+	t.Run("Missing dependencies of a pointer are returned", func(t *testing.T) {
+		type Dependency struct{}
+		type Service struct{}
+
+		c := New()
+		c.Provide(func(d *Dependency) *Service { return &Service{} })
+
+		var missing errs.DependencyResolutionError
+		service, err := OrElse(c, func() *Service { return &Service{} })
+		assert.Assert(t, errors.As(err, &missing))
+		assert.Assert(t, service == nil)
+	})
+
+	t.Run("Unprovided pointers use the default", func(t *testing.T) {
+		type Service struct{ Name string }
+
+		c := New()
+
+		service, err := OrElse(c, func() *Service { return &Service{Name: "default"} })
+		assert.NilError(t, err)
+		assert.Equal(t, service.Name, "default")
+	})
+
+	t.Run("Skipped types use the default", func(t *testing.T) {
+		type Service struct{ Name string }
+
+		c := New()
+		c.Provide(func() (string, error) { return "", ErrSkip })
+		c.Provide(func() (*Service, error) { return nil, ErrSkip })
+
+		text, err := OrElse(c, func() string { return "default" })
+		assert.NilError(t, err)
+		assert.Equal(t, text, "default")
+
+		service, err := OrElse(c, func() *Service { return &Service{Name: "default"} })
+		assert.NilError(t, err)
+		assert.Equal(t, service.Name, "default")
+	})
+
+	t.Run("Missing indirect dependencies of a pointer are returned", func(t *testing.T) {
+		type Database struct{}
+		type Repository struct{}
+		type Service struct{}
+
+		c := New()
+		c.Provide(func(d *Database) *Repository { return &Repository{} })
+		c.Provide(func(r *Repository) *Service { return &Service{} })
+
+		service, err := OrElse(c, func() *Service { return &Service{} })
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: ""})
+		assert.Assert(t, service == nil)
+	})
+
+	t.Run("Cyclic errors are returned", func(t *testing.T) {
+		c := New()
+		c.Provide(func(s string) int { return 0 })
+		c.Provide(func(n int) string { return "" })
+
+		_, err := OrElse(c, func() string { return "default" })
+		assert.ErrorIs(t, err, errs.CyclicDependencyError{TypeName: "string"})
+	})
+}
+
+func TestResolveByName(t *testing.T) {
+	t.Parallel()
+
+	newContainer := func() *Container {
+		c := New()
+		c.Provide(func() *strings.Reader { return strings.NewReader("strings") })
+		c.Provide(func() *bytes.Reader { return bytes.NewReader([]byte("bytes")) })
+		c.Provide(func() int { return 42 })
+		return c
+	}
+
+	t.Run("Types resolve by their full name", func(t *testing.T) {
+		c := newContainer()
+
+		value, err := c.ResolveByName("*strings.Reader")
+		assert.NilError(t, err)
+		assert.Equal(t, value.(*strings.Reader).Len(), len("strings"))
+
+		value, err = c.ResolveByName("int")
+		assert.NilError(t, err)
+		assert.Equal(t, value, 42)
+	})
+
+	t.Run("Types resolve by their import path", func(t *testing.T) {
+		c := New()
+		c.Provide(func() *errs.ErrorSet { return &errs.ErrorSet{} })
+
+		value, err := c.ResolveByName("*github.com/otoru/zeus/errs.ErrorSet")
+		assert.NilError(t, err)
+		assert.Assert(t, value.(*errs.ErrorSet).IsEmpty())
+	})
+
+	t.Run("Unique short names resolve", func(t *testing.T) {
+		c := New()
+		c.Provide(func() *strings.Builder { return &strings.Builder{} })
+
+		value, err := c.ResolveByName("*Builder")
+		assert.NilError(t, err)
+		assert.Equal(t, reflect.TypeOf(value), reflect.TypeOf(&strings.Builder{}))
+	})
+
+	t.Run("Ambiguous short names fail", func(t *testing.T) {
+		c := newContainer()
+
+		_, err := c.ResolveByName("*Reader")
+		assert.ErrorIs(t, err, errs.AmbiguousDependencyError{
+			TypeName:   "*Reader",
+			Candidates: []string{"*bytes.Reader", "*strings.Reader"},
+		})
+	})
+
+	t.Run("Unknown names fail", func(t *testing.T) {
+		c := newContainer()
+
+		_, err := c.ResolveByName("*io.Reader")
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "*io.Reader"})
+	})
+}
@@ -0,0 +1,65 @@
+package zeus
+
+import (
+	"reflect"
+	"sync"
+)
+
+var runCacheType = reflect.TypeOf((*RunCache)(nil)).Elem()
+
+// RunCache memoizes expensive work for the duration of a single run, apart from the singleton cache of the container.
+// Like Hooks, it is supplied by the container to any factory or function declaring a parameter of this type.
+// Everything resolved for one Run shares the same cache, so values computed under a key are reused within that run
+// but computed again by the next one. Resolutions outside of a Run, such as with Resolve, each get a cache of their own.
+// Singletons keep the cache of the run that built them: it is meant for values built again on every run,
+// such as with Prototype or the overrides of a run.
+//
+// Example:
+//
+//	c.Provide(func(cache zeus.RunCache, req *Request) (*User, error) {
+//	    user, err := cache.Get("user", func() (interface{}, error) { return loadUser(req) })
+//	    return user.(*User), err
+//	})
+type RunCache interface {
+	// Get returns the value computed for the given key in this run, calling compute the first time the key is requested.
+	// Errors returned by compute are kept for the key as well.
+	Get(key string, compute func() (interface{}, error)) (interface{}, error)
+}
+
+// runCache is the RunCache shared by the resolutions of a run.
+type runCache struct {
+	mu     sync.Mutex
+	values map[string]*memo
+}
+
+// memo is a value computed once for a key of a runCache.
+type memo struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// Get returns the value computed for the given key, calling compute the first time the key is requested.
+// Concurrent requests for the same key wait for the first computation, while other keys are computed independently.
+func (r *runCache) Get(key string, compute func() (interface{}, error)) (interface{}, error) {
+	r.mu.Lock()
+
+	if r.values == nil {
+		r.values = make(map[string]*memo)
+	}
+
+	entry, exists := r.values[key]
+
+	if !exists {
+		entry = new(memo)
+		r.values[key] = entry
+	}
+
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = compute()
+	})
+
+	return entry.value, entry.err
+}
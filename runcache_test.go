@@ -0,0 +1,111 @@
+package zeus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRunCache(t *testing.T) {
+	t.Parallel()
+
+	type Session struct{ Token string }
+	type Profile struct{ Token string }
+
+	newContainer := func(computed *int) *Container {
+		token := func(cache RunCache) string {
+			value, _ := cache.Get("token", func() (interface{}, error) {
+				*computed++
+				return "token", nil
+			})
+			return value.(string)
+		}
+
+		c := New(Prototype())
+		c.Provide(func(cache RunCache) *Session { return &Session{Token: token(cache)} })
+		c.Provide(func(cache RunCache) *Profile { return &Profile{Token: token(cache)} })
+		return c
+	}
+
+	t.Run("Dependents share a value within a run", func(t *testing.T) {
+		computed := 0
+		c := newContainer(&computed)
+
+		err := c.Run(func(s *Session, p *Profile) {
+			assert.Equal(t, s.Token, "token")
+			assert.Equal(t, p.Token, "token")
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, computed, 1)
+	})
+
+	t.Run("Separate runs compute the value again", func(t *testing.T) {
+		computed := 0
+		c := newContainer(&computed)
+
+		assert.NilError(t, c.Run(func(s *Session, p *Profile) {}))
+		assert.NilError(t, c.Run(func(s *Session, p *Profile) {}))
+		assert.Equal(t, computed, 2)
+	})
+
+	t.Run("Functions share the cache of their run", func(t *testing.T) {
+		computed := 0
+		c := newContainer(&computed)
+
+		err := c.Run(func(s *Session, cache RunCache) {
+			value, err := cache.Get("token", func() (interface{}, error) { return "other", nil })
+			assert.NilError(t, err)
+			assert.Equal(t, value, "token")
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, computed, 1)
+	})
+
+	t.Run("Errors are kept for their key", func(t *testing.T) {
+		cache := new(runCache)
+		failure := errors.New("lookup failed")
+		calls := 0
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.Get("user", func() (interface{}, error) {
+				calls++
+				return nil, failure
+			})
+			assert.ErrorIs(t, err, failure)
+		}
+
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Concurrent requests compute a key once", func(t *testing.T) {
+		cache := new(runCache)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		calls := 0
+
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				cache.Get("user", func() (interface{}, error) {
+					mu.Lock()
+					calls++
+					mu.Unlock()
+					return "user", nil
+				})
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("RunCache is supplied for validation", func(t *testing.T) {
+		computed := 0
+		c := newContainer(&computed)
+
+		assert.NilError(t, c.Validate())
+	})
+}
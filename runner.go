@@ -0,0 +1,128 @@
+package zeus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// RunnerState is the state of a Runner.
+type RunnerState int
+
+const (
+	// RunnerStopped is the state of a runner that was never started, or whose last run is over. It is the initial state.
+	RunnerStopped RunnerState = iota
+	// RunnerRunning is the state of a runner between a successful Start and the next Stop.
+	RunnerRunning
+)
+
+// Runner runs a function in the container like Run, split in two steps so that a supervisor can restart it:
+// Start resolves the dependencies, runs the start hooks and calls the function in its own goroutine,
+// and Stop runs the stop hooks, then waits for the function to return.
+// A runner goes from RunnerStopped to RunnerRunning with Start and back with Stop, as many times as needed,
+// each cycle running the hooks of its own run only. It is safe for concurrent use.
+type Runner struct {
+	container *Container
+	fn        interface{}
+
+	mu    sync.Mutex
+	state RunnerState
+	cycle *runnerCycle
+}
+
+// runnerCycle tracks a single run of a Runner, from Start to Stop.
+// Closing release lets the run stop, and done is closed once the run is over, err holding its errors.
+type runnerCycle struct {
+	started chan struct{}
+	release chan struct{}
+	done    chan struct{}
+	err     error
+}
+
+// Runner returns a handle running the provided function with the container each time it is started.
+// The signature of the function is checked by Start, as it is by Run.
+// A function that blocks, such as a server loop, must return once the stop hooks ran, for Stop to return.
+//
+// Example:
+//
+//	runner := c.Runner(func(s *Server) error { return s.Listen() })
+//	runner.Start()
+//	runner.Stop() // a stop hook shuts the server down, so Listen returns
+//	runner.Start() // restarts the workload
+func (c *Container) Runner(fn interface{}) *Runner {
+	return &Runner{container: c, fn: fn}
+}
+
+// State returns the current state of the runner.
+func (r *Runner) State() RunnerState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state
+}
+
+// Start resolves the dependencies of the function, runs the start hooks and calls the function in its own goroutine,
+// then returns without waiting for it, leaving the stop hooks to Stop. Returns the resolution and start errors like Run,
+// in which case the runner stays stopped, or an errs.RunnerRunningError if the runner is already running or starting.
+// An error returned by the function is reported by Stop.
+func (r *Runner) Start() error {
+	r.mu.Lock()
+
+	if r.cycle != nil {
+		r.mu.Unlock()
+		return errs.RunnerRunningError{}
+	}
+
+	cycle := &runnerCycle{started: make(chan struct{}), release: make(chan struct{}), done: make(chan struct{})}
+	r.cycle = cycle
+	r.mu.Unlock()
+
+	config := &runConfig{started: func() { close(cycle.started) }, release: cycle.release}
+
+	go func() {
+		_, cycle.err = r.container.run(context.Background(), r.fn, config)
+		close(cycle.done)
+	}()
+
+	select {
+	case <-cycle.started:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.cycle == cycle {
+			r.state = RunnerRunning
+		}
+
+		return nil
+	case <-cycle.done:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.cycle == cycle {
+			r.cycle = nil
+		}
+
+		return cycle.err
+	}
+}
+
+// Stop runs the stop hooks of the current run, waits for the function to return and returns the remaining errors:
+// the error of the function, then the error of each failing stop hook. It does nothing if the runner is not running.
+// A Stop while the runner is starting stops it as soon as its start hooks ran.
+func (r *Runner) Stop() error {
+	r.mu.Lock()
+	cycle := r.cycle
+	r.cycle = nil
+	r.state = RunnerStopped
+	r.mu.Unlock()
+
+	if cycle == nil {
+		return nil
+	}
+
+	close(cycle.release)
+	<-cycle.done
+
+	return cycle.err
+}
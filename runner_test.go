@@ -0,0 +1,148 @@
+package zeus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Hooks run once per cycle", func(t *testing.T) {
+		c := New()
+		starts, stops, calls := 0, 0, 0
+
+		c.Provide(func(h Hooks) int {
+			h.OnStart(func() error {
+				starts++
+				return nil
+			})
+
+			h.OnStop(func() error {
+				stops++
+				return nil
+			})
+
+			return 42
+		})
+
+		runner := c.Runner(func(number int) { calls++ })
+		assert.Equal(t, runner.State(), RunnerStopped)
+
+		for cycle := 1; cycle <= 3; cycle++ {
+			assert.NilError(t, runner.Start())
+			assert.Equal(t, runner.State(), RunnerRunning)
+			assert.Equal(t, starts, cycle)
+			assert.Equal(t, stops, cycle-1)
+
+			assert.NilError(t, runner.Stop())
+			assert.Equal(t, runner.State(), RunnerStopped)
+			assert.Equal(t, calls, cycle)
+			assert.Equal(t, stops, cycle)
+		}
+	})
+
+	t.Run("A blocking function is stopped and restarted", func(t *testing.T) {
+		type Server struct {
+			quit chan struct{}
+		}
+
+		c := New()
+		c.Provide(func(h Hooks) *Server {
+			s := &Server{}
+
+			h.OnStart(func() error {
+				s.quit = make(chan struct{})
+				return nil
+			})
+
+			h.OnStop(func() error {
+				close(s.quit)
+				return nil
+			})
+
+			return s
+		})
+
+		calls := 0
+		runner := c.Runner(func(s *Server) error {
+			calls++
+			<-s.quit
+			return nil
+		})
+
+		for cycle := 1; cycle <= 2; cycle++ {
+			assert.NilError(t, runner.Start())
+			assert.Equal(t, runner.State(), RunnerRunning)
+
+			assert.NilError(t, runner.Stop())
+			assert.Equal(t, runner.State(), RunnerStopped)
+			assert.Equal(t, calls, cycle)
+		}
+	})
+
+	t.Run("Starting a running runner", func(t *testing.T) {
+		c := New()
+		runner := c.Runner(func() {})
+
+		assert.NilError(t, runner.Start())
+		defer runner.Stop()
+
+		assert.ErrorIs(t, runner.Start(), errs.RunnerRunningError{})
+	})
+
+	t.Run("Concurrent starts run the function once", func(t *testing.T) {
+		c := New()
+		var mu sync.Mutex
+		calls := 0
+
+		runner := c.Runner(func() {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+		})
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				runner.Start()
+			}()
+		}
+
+		wg.Wait()
+		assert.NilError(t, runner.Stop())
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("Failed start leaves the runner stopped", func(t *testing.T) {
+		c := New()
+		runner := c.Runner(func(s string) {})
+
+		err := runner.Start()
+		assert.ErrorIs(t, err, errs.DependencyResolutionError{TypeName: "string"})
+		assert.Equal(t, runner.State(), RunnerStopped)
+	})
+
+	t.Run("Stop reports the function error", func(t *testing.T) {
+		c := New()
+		runner := c.Runner(func() error { return errors.New("function error") })
+
+		assert.NilError(t, runner.Start())
+		assert.Error(t, runner.Stop(), "function error")
+	})
+
+	t.Run("Stopping a stopped runner", func(t *testing.T) {
+		c := New()
+		runner := c.Runner(func() {})
+
+		assert.NilError(t, runner.Stop())
+	})
+}
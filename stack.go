@@ -0,0 +1,155 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// stackSetThreshold is the depth past which a typeStack indexes its types in a set.
+// Shallow stacks are scanned linearly, which is faster than hashing and spares the allocation of the set.
+const stackSetThreshold = 8
+
+// typeStack lists the types currently being constructed, in order, to detect cycles while resolving.
+// It is pushed and popped as the resolution goes deeper and back, so it must not be shared between goroutines:
+// use clone to hand it over. It also carries the RunCache of the run the resolution belongs to.
+type typeStack struct {
+	types    []reflect.Type
+	seen     map[reflect.Type]int
+	cache    *runCache
+	captures []*stackCapture
+}
+
+// stackCapture is a copy of a typeStack kept by code resolving later on, such as a Provider[T].
+// It is released once the construction it was taken in is over, since its types are no longer being constructed.
+type stackCapture struct {
+	stack    *typeStack
+	depth    int
+	released atomic.Bool
+}
+
+// newTypeStack returns a stack seeded with a copy of the given types.
+func newTypeStack(types []reflect.Type) *typeStack {
+	stack := &typeStack{types: make([]reflect.Type, 0, len(types))}
+
+	for _, t := range types {
+		stack.push(t)
+	}
+
+	return stack
+}
+
+// contains reports whether the given type is being constructed.
+func (s *typeStack) contains(t reflect.Type) bool {
+	if s == nil {
+		return false
+	}
+
+	if s.seen != nil {
+		return s.seen[t] > 0
+	}
+
+	return slices.Contains(s.types, t)
+}
+
+// push records the given type as being constructed.
+func (s *typeStack) push(t reflect.Type) {
+	s.types = append(s.types, t)
+
+	if s.seen != nil {
+		s.seen[t]++
+		return
+	}
+
+	if len(s.types) > stackSetThreshold {
+		s.seen = make(map[reflect.Type]int, len(s.types))
+
+		for _, pushed := range s.types {
+			s.seen[pushed]++
+		}
+	}
+}
+
+// pop removes the last type pushed, once its construction is over, releasing the captures taken during it.
+func (s *typeStack) pop() {
+	t := s.types[len(s.types)-1]
+	s.types = s.types[:len(s.types)-1]
+
+	for len(s.captures) > 0 && s.captures[len(s.captures)-1].depth > len(s.types) {
+		s.captures[len(s.captures)-1].released.Store(true)
+		s.captures = s.captures[:len(s.captures)-1]
+	}
+
+	if s.seen == nil {
+		return
+	}
+
+	if s.seen[t]--; s.seen[t] == 0 {
+		delete(s.seen, t)
+	}
+}
+
+// top returns the type being constructed by the innermost factory, or false if the stack is empty.
+func (s *typeStack) top() (reflect.Type, bool) {
+	if s == nil || len(s.types) == 0 {
+		return nil, false
+	}
+
+	return s.types[len(s.types)-1], true
+}
+
+// path returns a copy of the types being constructed, from the outermost to the innermost.
+func (s *typeStack) path() []reflect.Type {
+	if s == nil {
+		return nil
+	}
+
+	return slices.Clone(s.types)
+}
+
+// runCache returns the RunCache of the resolution, creating it on first use.
+func (s *typeStack) runCache() *runCache {
+	if s.cache == nil {
+		s.cache = new(runCache)
+	}
+
+	return s.cache
+}
+
+// clone returns an independent copy of the stack, sharing its RunCache.
+func (s *typeStack) clone() *typeStack {
+	if s == nil {
+		return newTypeStack(nil)
+	}
+
+	stack := newTypeStack(s.path())
+	stack.cache = s.runCache()
+
+	return stack
+}
+
+// capture returns a copy of the stack that stays valid while the construction in progress is not over,
+// to be used by resolutions happening later on.
+func (s *typeStack) capture() *stackCapture {
+	capture := &stackCapture{stack: s.clone()}
+
+	if s != nil && len(s.types) > 0 {
+		capture.depth = len(s.types)
+		s.captures = append(s.captures, capture)
+	}
+
+	return capture
+}
+
+// current returns a stack to resolve with: a copy of the captured one while its construction is in progress,
+// or an empty one sharing its RunCache once it is over, so that the types built since are not seen as cycles.
+func (c *stackCapture) current() *typeStack {
+	if !c.released.Load() {
+		return c.stack.clone()
+	}
+
+	stack := newTypeStack(nil)
+	stack.cache = c.stack.runCache()
+
+	return stack
+}
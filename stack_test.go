@@ -0,0 +1,75 @@
+package zeus
+
+import (
+	"reflect"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTypeStack(t *testing.T) {
+	t.Parallel()
+
+	chain := func(depth int) []reflect.Type {
+		types := []reflect.Type{reflect.TypeOf(0)}
+		for len(types) < depth {
+			types = append(types, reflect.PointerTo(types[len(types)-1]))
+		}
+		return types
+	}
+
+	t.Run("Detects pushed types past the threshold", func(t *testing.T) {
+		types := chain(stackSetThreshold * 2)
+		stack := newTypeStack(nil)
+
+		for _, typ := range types {
+			assert.Assert(t, !stack.contains(typ))
+			stack.push(typ)
+			assert.Assert(t, stack.contains(typ))
+		}
+
+		for i := len(types) - 1; i >= 0; i-- {
+			stack.pop()
+			assert.Assert(t, !stack.contains(types[i]))
+		}
+		assert.Equal(t, len(stack.path()), 0)
+	})
+
+	t.Run("Keeps types pushed twice until both are popped", func(t *testing.T) {
+		types := chain(stackSetThreshold + 1)
+		stack := newTypeStack(types)
+		stack.push(types[0])
+
+		stack.pop()
+		assert.Assert(t, stack.contains(types[0]))
+	})
+
+	t.Run("Keeps the path in order", func(t *testing.T) {
+		types := chain(stackSetThreshold + 2)
+		stack := newTypeStack(types)
+
+		top, ok := stack.top()
+		assert.Assert(t, ok)
+		assert.Equal(t, top, types[len(types)-1])
+		assert.DeepEqual(t, typeNames(stack.path()), typeNames(types))
+	})
+
+	t.Run("Clones are independent", func(t *testing.T) {
+		types := chain(stackSetThreshold + 2)
+		stack := newTypeStack(types)
+		clone := stack.clone()
+
+		clone.pop()
+		assert.Assert(t, stack.contains(types[len(types)-1]))
+		assert.Assert(t, !clone.contains(types[len(types)-1]))
+	})
+
+	t.Run("Nil stack is empty", func(t *testing.T) {
+		var stack *typeStack
+
+		_, ok := stack.top()
+		assert.Assert(t, !ok)
+		assert.Assert(t, !stack.contains(reflect.TypeOf(0)))
+		assert.Equal(t, len(stack.clone().path()), 0)
+	})
+}
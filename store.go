@@ -0,0 +1,180 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/otoru/zeus/hooks"
+)
+
+// InstanceStore keeps the singleton values built by a container, in place of its default in-memory map.
+// Implementations may evict values, for instance after a TTL or with an LRU policy: an evicted type is built again
+// on its next resolution. Delete is called when the container discards a value, such as with ResetComputed,
+// RemoveDecorators or a late registration. Implementations must be safe for concurrent use and must not call back into the container.
+type InstanceStore interface {
+	Get(t reflect.Type) (reflect.Value, bool)
+	Set(t reflect.Type, value reflect.Value)
+	Delete(t reflect.Type)
+}
+
+// SetInstanceStore makes the container read and write its singleton values through the given store.
+// Values cached before the call are not copied over. ResetComputed, RemoveDecorators and late registrations
+// discard values through Delete, while Validate and OverrideFor only see the default in-memory map,
+// so runs with overrides rebuild every value they need.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.SetInstanceStore(newTTLStore(time.Minute))
+func (c *Container) SetInstanceStore(store InstanceStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store = store
+}
+
+// CachedTypes returns the types whose singleton value is currently held by the container, sorted by name.
+// Unlike Types, which lists what the container can build, it only reports what was built and kept,
+// which helps reasoning about the memory retained by a long-running process.
+// Values kept by a store set with SetInstanceStore are not reported, since stores cannot be enumerated.
+//
+// Example:
+//
+//	c.Run(serve)
+//	for _, t := range c.CachedTypes() {
+//	    fmt.Println(t, "is retained")
+//	}
+func (c *Container) CachedTypes() []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	types := make([]reflect.Type, 0, len(c.instances))
+
+	for t := range c.instances {
+		types = append(types, t)
+	}
+
+	return sortTypes(types)
+}
+
+// cached returns the singleton value of the given type, if any. The caller must hold the lock.
+func (c *Container) cached(t reflect.Type) (reflect.Value, bool) {
+	if c.store != nil {
+		return c.store.Get(t)
+	}
+
+	instance, exists := c.instances[t]
+	return instance, exists
+}
+
+// uncache discards the singleton value of the given type, if any. The caller must hold the lock for writing.
+func (c *Container) uncache(t reflect.Type) {
+	if c.store != nil {
+		c.store.Delete(t)
+		return
+	}
+
+	delete(c.instances, t)
+}
+
+// heldTypes returns the types whose singleton value is currently held. The caller must hold the lock.
+// Stores cannot be enumerated, so with one, the registered types are each looked up in it instead.
+func (c *Container) heldTypes() []reflect.Type {
+	if c.store == nil {
+		types := make([]reflect.Type, 0, len(c.instances))
+
+		for t := range c.instances {
+			types = append(types, t)
+		}
+
+		return types
+	}
+
+	types := []reflect.Type{}
+
+	for t := range c.providers {
+		if _, exists := c.store.Get(t); exists {
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
+
+// cache keeps the singleton value of the given type. The caller must hold the lock for writing.
+// With CacheSize, the least recently resolved values are evicted to make room for it, and the stop hooks
+// to run for them right away are returned, or nil without a size.
+func (c *Container) cache(t reflect.Type, value reflect.Value) *hooks.LifecycleHooks {
+	if c.store != nil {
+		c.store.Set(t, value)
+		return nil
+	}
+
+	c.instances[t] = value
+
+	if c.capacity <= 0 {
+		return nil
+	}
+
+	c.recent = append(slices.DeleteFunc(c.recent, func(recent reflect.Type) bool { return recent == t }), t)
+	evicted := new(hooks.LifecycleHooks)
+
+	for len(c.instances) > c.capacity && len(c.recent) > 1 {
+		oldest := c.recent[0]
+		c.recent = slices.Delete(c.recent, 0, 1)
+
+		if _, exists := c.instances[oldest]; !exists {
+			continue
+		}
+
+		delete(c.instances, oldest)
+
+		if provider, exists := c.providers[oldest]; exists {
+			delete(c.tuples, provider.Type())
+		}
+
+		c.records = c.evictRecords(oldest, evicted)
+	}
+
+	return evicted
+}
+
+// evictRecords returns the hook records left once the value of the given type is evicted, adding to stopped
+// the hooks to run right away. The caller must hold the lock for writing.
+// While the container is started with Build, the records are stopped and dropped. Otherwise, records whose hooks
+// are still to run, since no Run nor Build settled them yet or a Run in progress may run them again, are kept
+// and marked evicted, so that they are dropped once settled. Other records are dropped, since their hooks already ran.
+func (c *Container) evictRecords(t reflect.Type, stopped *hooks.LifecycleHooks) []hookRecord {
+	kept := c.records[:0]
+
+	for _, record := range c.records {
+		switch {
+		case record.t != t || record.evicted:
+		case c.started:
+			stopped.Append(record.stage)
+			continue
+		case !record.settled || c.running.Load() > 0:
+			record.evicted = true
+		default:
+			continue
+		}
+
+		kept = append(kept, record)
+	}
+
+	return kept
+}
+
+// touch marks the given type as the most recently resolved one, so that CacheSize evicts it last.
+func (c *Container) touch(t reflect.Type) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i := slices.Index(c.recent, t); i >= 0 {
+		c.recent = append(slices.Delete(c.recent, i, i+1), t)
+	}
+}
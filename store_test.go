@@ -0,0 +1,314 @@
+package zeus
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+// fakeStore is an InstanceStore recording the types read and written through it.
+type fakeStore struct {
+	mu     sync.Mutex
+	values map[reflect.Type]reflect.Value
+	gets   []reflect.Type
+	sets   []reflect.Type
+}
+
+func (s *fakeStore) Get(t reflect.Type) (reflect.Value, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gets = append(s.gets, t)
+	value, exists := s.values[t]
+	return value, exists
+}
+
+func (s *fakeStore) Set(t reflect.Type, value reflect.Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sets = append(s.sets, t)
+	s.values[t] = value
+}
+
+func (s *fakeStore) Delete(t reflect.Type) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.values, t)
+}
+
+func TestInstanceStore(t *testing.T) {
+	t.Parallel()
+
+	newStore := func() *fakeStore {
+		return &fakeStore{values: map[reflect.Type]reflect.Value{}}
+	}
+
+	t.Run("ResetComputed deletes from the store", func(t *testing.T) {
+		c := New()
+		store := newStore()
+		c.SetInstanceStore(store)
+
+		calls := 0
+		c.Provide(func() int { calls++; return 42 })
+		c.Supply("Hello")
+
+		c.Run(func(i int, s string) {})
+		c.ResetComputed()
+		c.Run(func(i int, s string) {})
+
+		assert.Equal(t, calls, 2)
+		assert.Equal(t, store.values[reflect.TypeOf("")].String(), "Hello")
+	})
+
+	t.Run("Late registrations invalidate the store", func(t *testing.T) {
+		type Settings struct{ Name string }
+
+		c := New(OnLateProvide(LateInvalidate))
+		c.SetInstanceStore(newStore())
+		c.Provide(func() *Settings { return &Settings{Name: "default"} })
+		c.Provide(func(s *Settings) string { return s.Name })
+		c.ResolveType(reflect.TypeOf(""))
+
+		assert.NilError(t, c.Decorate(func(s *Settings) *Settings { return &Settings{Name: s.Name + "+custom"} }))
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "default+custom")
+	})
+
+	t.Run("Late registrations are detected in the store", func(t *testing.T) {
+		c := New(OnLateProvide(LateError))
+		c.SetInstanceStore(newStore())
+		c.Provide(func() int { return 42 })
+		c.ResolveType(reflect.TypeOf(0))
+
+		err := c.Decorate(func(i int) int { return i + 1 })
+		assert.ErrorIs(t, err, errs.LateProvideError{TypeName: "int", ResolvedName: "int"})
+	})
+
+	t.Run("RemoveDecorators deletes from the store", func(t *testing.T) {
+		c := New()
+		c.SetInstanceStore(newStore())
+		c.Provide(func() string { return "base" })
+		c.Decorate(func(inner string) string { return inner + "+traced" })
+		c.ResolveType(reflect.TypeOf(""))
+
+		assert.NilError(t, c.RemoveDecorators(""))
+
+		val, err := c.ResolveType(reflect.TypeOf(""))
+		assert.NilError(t, err)
+		assert.Equal(t, val.String(), "base")
+	})
+
+	t.Run("Reads and writes through the store", func(t *testing.T) {
+		c := New()
+		store := &fakeStore{values: map[reflect.Type]reflect.Value{}}
+		c.SetInstanceStore(store)
+
+		calls := 0
+		c.Provide(func() int { calls++; return 42 })
+
+		c.Run(func(i int) {})
+		c.Run(func(i int) {})
+
+		intType := reflect.TypeOf(0)
+		assert.Equal(t, calls, 1)
+		assert.Assert(t, len(store.gets) >= 2)
+		assert.DeepEqual(t, typeNames(store.sets), []string{"int"})
+		assert.Equal(t, store.values[intType].Int(), int64(42))
+		assert.Equal(t, len(c.instances), 0)
+	})
+
+	t.Run("Evicted values are rebuilt", func(t *testing.T) {
+		c := New()
+		store := &fakeStore{values: map[reflect.Type]reflect.Value{}}
+		c.SetInstanceStore(store)
+
+		calls := 0
+		c.Provide(func() int { calls++; return calls })
+
+		c.Run(func(i int) {})
+		delete(store.values, reflect.TypeOf(0))
+
+		err := c.Run(func(i int) { assert.Equal(t, i, 2) })
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 2)
+	})
+}
+
+func TestCachedTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reports types once resolved", func(t *testing.T) {
+		c := New()
+		c.Provide(func() int { return 42 }, func(i int) string { return "" }, func() bool { return true })
+		assert.DeepEqual(t, typeNames(c.CachedTypes()), []string{})
+
+		assert.NilError(t, c.Run(func(string) {}))
+		assert.DeepEqual(t, typeNames(c.CachedTypes()), []string{"int", "string"})
+	})
+
+	t.Run("Prototype containers keep nothing", func(t *testing.T) {
+		c := New(Prototype())
+		c.Provide(func() int { return 42 })
+
+		assert.NilError(t, c.Run(func(int) {}))
+		assert.DeepEqual(t, typeNames(c.CachedTypes()), []string{})
+	})
+}
+
+func TestCacheSize(t *testing.T) {
+	t.Parallel()
+
+	type First struct{}
+	type Second struct{}
+	type Third struct{}
+
+	// newContainer provides the three types, counting the builds and the stop hooks of each of them.
+	newContainer := func(builds, stops map[string]int) *Container {
+		c := New(CacheSize(2))
+		var mu sync.Mutex
+
+		track := func(name string, h Hooks) {
+			mu.Lock()
+			builds[name]++
+			mu.Unlock()
+
+			h.OnStop(func() error {
+				mu.Lock()
+				stops[name]++
+				mu.Unlock()
+				return nil
+			})
+		}
+
+		c.Provide(func(h Hooks) *First { track("first", h); return &First{} })
+		c.Provide(func(h Hooks) *Second { track("second", h); return &Second{} })
+		c.Provide(func(h Hooks) *Third { track("third", h); return &Third{} })
+
+		return c
+	}
+
+	t.Run("Evicts the least recently resolved value", func(t *testing.T) {
+		builds, stops := map[string]int{}, map[string]int{}
+		c := newContainer(builds, stops)
+
+		c.Run(func(f *First) {})
+		c.Run(func(s *Second) {})
+		c.Run(func(f *First) {})
+		c.Run(func(t *Third) {})
+
+		assert.Equal(t, len(c.instances), 2)
+		_, cached := c.instances[reflect.TypeOf(&Second{})]
+		assert.Assert(t, !cached)
+
+		c.Run(func(s *Second) {})
+		assert.Equal(t, builds["second"], 2)
+		assert.Equal(t, builds["first"], 1)
+	})
+
+	t.Run("Stops evicted values of a started container", func(t *testing.T) {
+		c := New(CacheSize(2))
+		var stopped []string
+
+		c.Provide(func(h Hooks) *First {
+			h.OnStop(func() error { stopped = append(stopped, "first"); return nil })
+			return &First{}
+		})
+		c.Provide(func(h Hooks) *Second {
+			h.OnStop(func() error { stopped = append(stopped, "second"); return nil })
+			return &Second{}
+		})
+		assert.NilError(t, c.Build())
+
+		c.Provide(func(h Hooks) *Third {
+			h.OnStop(func() error { stopped = append(stopped, "third"); return nil })
+			return &Third{}
+		})
+
+		err := c.Run(func(t *Third) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, stopped, []string{"first"})
+
+		assert.NilError(t, c.Close())
+		assert.DeepEqual(t, stopped, []string{"first", "third", "second"})
+	})
+
+	t.Run("Hooks of evicted values are not run twice", func(t *testing.T) {
+		builds, stops := map[string]int{}, map[string]int{}
+		c := newContainer(builds, stops)
+
+		c.Run(func(f *First) {})
+		c.Run(func(s *Second) {})
+		c.Run(func(t *Third) {})
+
+		assert.Equal(t, stops["first"], 1)
+		assert.Equal(t, stops["second"], 1)
+		assert.Equal(t, stops["third"], 1)
+	})
+
+	t.Run("Values evicted outside of a Run are stopped by Close", func(t *testing.T) {
+		builds, stops := map[string]int{}, map[string]int{}
+		c := newContainer(builds, stops)
+
+		Resolve[*First](c)
+		Resolve[*Second](c)
+		Resolve[*Third](c)
+
+		_, cached := c.instances[reflect.TypeOf(&First{})]
+		assert.Assert(t, !cached)
+		assert.Equal(t, stops["first"], 0)
+
+		assert.NilError(t, c.Close())
+		assert.Equal(t, stops["first"], 1)
+		assert.Equal(t, stops["second"], 1)
+		assert.Equal(t, stops["third"], 1)
+	})
+
+	t.Run("Values used by a Run are stopped with it", func(t *testing.T) {
+		builds, stops := map[string]int{}, map[string]int{}
+		c := newContainer(builds, stops)
+
+		err := c.Run(func(f *First) {
+			Resolve[*Second](c)
+			Resolve[*Third](c)
+
+			_, cached := c.instances[reflect.TypeOf(&First{})]
+			assert.Assert(t, !cached)
+			assert.Equal(t, stops["first"], 0)
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, stops["first"], 1)
+
+		assert.NilError(t, c.Close())
+		assert.Equal(t, stops["first"], 1)
+	})
+
+	t.Run("Values evicted while a Run resolves keep their hooks", func(t *testing.T) {
+		c := New(CacheSize(2))
+		starts, stops := map[string]int{}, map[string]int{}
+
+		track := func(name string, h Hooks) {
+			h.OnStart(func() error { starts[name]++; return nil })
+			h.OnStop(func() error { stops[name]++; return nil })
+		}
+
+		c.Provide(func(h Hooks) *First { track("first", h); return &First{} })
+		c.Provide(func(h Hooks) *Second { track("second", h); return &Second{} })
+		c.Provide(func(h Hooks) *Third { track("third", h); return &Third{} })
+
+		err := c.Run(func(f *First, s *Second, t *Third) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, starts, map[string]int{"first": 1, "second": 1, "third": 1})
+		assert.DeepEqual(t, stops, map[string]int{"first": 1, "second": 1, "third": 1})
+
+		assert.NilError(t, c.Close())
+		assert.DeepEqual(t, stops, map[string]int{"first": 1, "second": 1, "third": 1})
+		assert.Equal(t, len(c.records), 2)
+	})
+}
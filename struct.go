@@ -0,0 +1,259 @@
+package zeus
+
+import (
+	"reflect"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// optionalTag marks a struct field that ProvideStruct leaves to its zero value when nothing can build its type.
+const optionalTag = "optional"
+
+// provideTag marks a struct field that ProvideFromConfig registers a factory for.
+const provideTag = "provide"
+
+// fieldResolverType is the hidden parameter of the factories generated by ProvideStruct.
+var fieldResolverType = reflect.TypeOf(fieldResolver{})
+
+// fieldResolver resolves the optional fields of a struct built by ProvideStruct.
+// It is supplied by the container like Hooks, carrying the stack of the construction to keep cycle detection working.
+type fieldResolver struct {
+	container *Container
+	stack     *typeStack
+}
+
+// resolve returns the value of the given type, or false if nothing can build it.
+func (r fieldResolver) resolve(t reflect.Type) (reflect.Value, bool, error) {
+	if _, ok := r.container.checkDependency(t); !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	value, err := r.container.resolve(t, r.stack)
+
+	return value, err == nil, err
+}
+
+// withOptional records the types the factory resolves only when something can build them, such as the optional fields
+// of ProvideStruct, so that the graph, validation and lifecycle code see them along with its parameters.
+func withOptional(types []reflect.Type) ProvideOption {
+	return func(config *provideConfig) {
+		config.optional = append(config.optional, types...)
+	}
+}
+
+// ProvideStruct registers a factory building the type of sample, a struct or a pointer to one, without a constructor.
+// Every exported field is resolved from the container, while fields tagged `zeus:"optional"`
+// are left to their zero value when nothing can build their type. ProvideOption values apply like with Provide.
+// Returns an errs.NotAStructError if sample is not a struct or a pointer to one.
+//
+// Example:
+//
+//	type Server struct {
+//	    Config  Config
+//	    Handler http.Handler
+//	    Metrics *Metrics `zeus:"optional"`
+//	}
+//
+//	c.ProvideStruct(&Server{})
+func (c *Container) ProvideStruct(sample interface{}, options ...ProvideOption) error {
+	providedType := reflect.TypeOf(sample)
+	structType := providedType
+
+	if structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType == nil {
+		return errs.NilValueError{}
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return errs.NotAStructError{TypeName: providedType.String()}
+	}
+
+	required, optional := []int{}, []int{}
+	in := []reflect.Type{fieldResolverType}
+	optionalTypes := []reflect.Type{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Tag.Get("zeus") == optionalTag {
+			optional = append(optional, i)
+			optionalTypes = append(optionalTypes, field.Type)
+			continue
+		}
+
+		required = append(required, i)
+		in = append(in, field.Type)
+	}
+
+	factoryType := reflect.FuncOf(in, []reflect.Type{providedType, errorType}, false)
+	factory := reflect.MakeFunc(factoryType, func(args []reflect.Value) []reflect.Value {
+		resolver := args[0].Interface().(fieldResolver)
+		value := reflect.New(structType)
+
+		for j, i := range required {
+			value.Elem().Field(i).Set(args[j+1])
+		}
+
+		for _, i := range optional {
+			field, ok, err := resolver.resolve(structType.Field(i).Type)
+
+			if err != nil {
+				return []reflect.Value{reflect.Zero(providedType), reflect.ValueOf(&err).Elem()}
+			}
+
+			if ok {
+				value.Elem().Field(i).Set(field)
+			}
+		}
+
+		if providedType.Kind() != reflect.Pointer {
+			value = value.Elem()
+		}
+
+		return []reflect.Value{value, reflect.Zero(errorType)}
+	})
+
+	args := []interface{}{factory.Interface(), withOptional(optionalTypes)}
+
+	for _, option := range options {
+		args = append(args, option)
+	}
+
+	return c.Provide(args...)
+}
+
+// ProvideFromConfig registers a factory for each exported field of cfg tagged `zeus:"provide"`,
+// returning the value of the field under its type. cfg is a struct or a pointer to one: with a pointer,
+// factories read the field when they run, so changes made to the config before resolution are seen.
+// Untagged and unexported fields are ignored. Returns an errs.NotAStructError if cfg is not a struct or a pointer to one,
+// or the errors of the fields that could not be registered, aggregated.
+//
+// Example:
+//
+//	type Config struct {
+//	    Addr    string        `zeus:"provide"`
+//	    Timeout time.Duration `zeus:"provide"`
+//	}
+//
+//	c.ProvideFromConfig(&cfg)
+func (c *Container) ProvideFromConfig(cfg interface{}) error {
+	value, err := structValue(cfg)
+
+	if err != nil {
+		return err
+	}
+
+	errorSet := &errs.ErrorSet{}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		if !field.IsExported() || field.Tag.Get("zeus") != provideTag {
+			continue
+		}
+
+		if err := c.Provide(fieldFactory(value.Field(i))); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	return errorSet.Result()
+}
+
+// ProvideSections registers a factory for each exported field of root whose type is a struct or a pointer to one,
+// returning the value of the field under its type, so that each section of a configuration can be depended on alone.
+// root is a struct or a pointer to one, read like with ProvideFromConfig, and is not registered itself.
+// Returns an errs.FactoryAlreadyProvidedError without registering anything if two sections share a type,
+// an errs.NotAStructError if root is not a struct or a pointer to one, or the errors of the sections that could not be
+// registered, aggregated.
+//
+// Example:
+//
+//	type AppConfig struct {
+//	    DB   DBConfig
+//	    HTTP *HTTPConfig
+//	}
+//
+//	c.ProvideSections(&cfg)
+//	c.Run(func(db DBConfig) { /* ... */ })
+func (c *Container) ProvideSections(root interface{}) error {
+	value, err := structValue(root)
+
+	if err != nil {
+		return err
+	}
+
+	sections := []int{}
+	seen := make(map[reflect.Type]bool)
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		sectionType := field.Type
+
+		if sectionType.Kind() == reflect.Pointer {
+			sectionType = sectionType.Elem()
+		}
+
+		if !field.IsExported() || sectionType.Kind() != reflect.Struct {
+			continue
+		}
+
+		if seen[field.Type] {
+			return errs.FactoryAlreadyProvidedError{TypeName: field.Type.String()}
+		}
+
+		seen[field.Type] = true
+		sections = append(sections, i)
+	}
+
+	errorSet := &errs.ErrorSet{}
+
+	for _, i := range sections {
+		if err := c.Provide(fieldFactory(value.Field(i))); err != nil {
+			errorSet.Add(err)
+		}
+	}
+
+	return errorSet.Result()
+}
+
+// structValue returns the struct held by cfg, or pointed to by it.
+// Returns an errs.NilValueError if cfg is nil, or an errs.NotAStructError if it is not a struct or a pointer to one.
+func structValue(cfg interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(cfg)
+
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, errs.NilValueError{}
+		}
+
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return reflect.Value{}, errs.NilValueError{}
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, errs.NotAStructError{TypeName: reflect.TypeOf(cfg).String()}
+	}
+
+	return value, nil
+}
+
+// fieldFactory returns a factory taking no parameter and returning the given field under its type.
+func fieldFactory(field reflect.Value) interface{} {
+	factoryType := reflect.FuncOf(nil, []reflect.Type{field.Type()}, false)
+	factory := reflect.MakeFunc(factoryType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{field}
+	})
+
+	return factory.Interface()
+}
@@ -0,0 +1,262 @@
+package zeus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestProvideStruct(t *testing.T) {
+	t.Parallel()
+
+	type Config struct{ Addr string }
+	type Metrics struct{}
+	type Tracer struct{}
+
+	type Server struct {
+		Config  Config
+		Metrics *Metrics `zeus:"optional"`
+		Tracer  *Tracer  `zeus:"optional"`
+		name    string
+	}
+
+	t.Run("Builds the struct from its fields", func(t *testing.T) {
+		c := New()
+		c.Provide(func() Config { return Config{Addr: ":8080"} })
+		c.Provide(func() *Metrics { return &Metrics{} })
+
+		err := c.ProvideStruct(&Server{})
+		assert.NilError(t, err)
+		assert.NilError(t, c.Validate())
+
+		var server *Server
+		err = c.Run(func(s *Server) { server = s })
+		assert.NilError(t, err)
+		assert.Equal(t, server.Config.Addr, ":8080")
+		assert.Assert(t, server.Metrics != nil)
+		assert.Assert(t, server.Tracer == nil)
+		assert.Equal(t, server.name, "")
+	})
+
+	t.Run("Registered as a provider of the struct value", func(t *testing.T) {
+		c := New()
+		c.Provide(func() Config { return Config{Addr: ":8080"} })
+		assert.NilError(t, c.ProvideStruct(Server{}))
+
+		val, err := c.resolve(reflect.TypeOf(Server{}), nil)
+		assert.NilError(t, err)
+		assert.Equal(t, val.Interface().(Server).Config.Addr, ":8080")
+
+		assert.DeepEqual(t, typeNames(c.Graph()[reflect.TypeOf(Server{})]), []string{"zeus.Config"})
+	})
+
+	t.Run("Optional fields are part of the graph", func(t *testing.T) {
+		c := New()
+		started, stopped := false, false
+		c.Provide(func() Config { return Config{} })
+		c.Provide(func(h Hooks) *Metrics {
+			h.OnStart(func() error { started = true; return nil })
+			h.OnStop(func() error { stopped = true; return nil })
+			return &Metrics{}
+		})
+		c.ProvideStruct(&Server{})
+
+		serverType := reflect.TypeOf(&Server{})
+		assert.DeepEqual(t, typeNames(c.Graph()[serverType]), []string{"*zeus.Metrics", "zeus.Config"})
+		assert.NilError(t, c.Validate())
+
+		plan, err := c.Plan(func(s *Server) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, typeNames(plan), []string{"zeus.Config", "*zeus.Metrics", "*zeus.Server"})
+
+		assert.NilError(t, c.Run(func(s *Server) {}))
+		assert.Assert(t, started)
+		assert.Assert(t, stopped)
+	})
+
+	t.Run("Missing required field", func(t *testing.T) {
+		c := New()
+		c.ProvideStruct(&Server{})
+
+		_, got := c.resolve(reflect.TypeOf(&Server{}), nil)
+		assert.ErrorIs(t, got, errs.DependencyResolutionError{TypeName: "Config"})
+	})
+
+	t.Run("Failing optional field", func(t *testing.T) {
+		c := New()
+		failure := errors.New("metrics unavailable")
+		c.Provide(func() Config { return Config{} })
+		c.Provide(func() (*Metrics, error) { return nil, failure })
+		c.ProvideStruct(&Server{})
+
+		_, got := c.resolve(reflect.TypeOf(&Server{}), nil)
+		assert.ErrorIs(t, got, failure)
+	})
+
+	t.Run("Optional field cycle", func(t *testing.T) {
+		type Node struct {
+			Next *Node `zeus:"optional"`
+		}
+
+		c := New()
+		c.ProvideStruct(&Node{})
+
+		_, got := c.resolve(reflect.TypeOf(&Node{}), nil)
+		assert.ErrorIs(t, got, errs.CyclicDependencyError{TypeName: ""})
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		c := New()
+		got := c.ProvideStruct(42)
+
+		assert.ErrorIs(t, got, errs.NotAStructError{TypeName: "int"})
+	})
+
+	t.Run("Accepts provide options", func(t *testing.T) {
+		type Addresser interface{ Address() string }
+
+		c := New()
+		c.Provide(func() string { return ":8080" })
+		err := c.ProvideStruct(&addressed{}, As(new(Addresser)))
+		assert.NilError(t, err)
+
+		var addr string
+		err = c.Run(func(a Addresser) { addr = a.Address() })
+		assert.NilError(t, err)
+		assert.Equal(t, addr, ":8080")
+	})
+}
+
+// addressed is a struct built by ProvideStruct in tests, implementing an interface.
+type addressed struct {
+	Addr string
+}
+
+func (a *addressed) Address() string {
+	return a.Addr
+}
+
+func TestProvideFromConfig(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Addr    string        `zeus:"provide"`
+		Timeout time.Duration `zeus:"provide"`
+		Debug   bool
+		secret  int `zeus:"provide"`
+	}
+
+	t.Run("Provides tagged fields", func(t *testing.T) {
+		cfg := &Config{Addr: ":8080", Timeout: time.Second, secret: 42}
+
+		c := New()
+		assert.NilError(t, c.ProvideFromConfig(cfg))
+		cfg.Addr = ":9090"
+
+		err := c.Run(func(addr string, timeout time.Duration) {
+			assert.Equal(t, addr, ":9090")
+			assert.Equal(t, timeout, time.Second)
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, typeNames(c.Types()), []string{"string", "time.Duration"})
+	})
+
+	t.Run("Accepts a struct value", func(t *testing.T) {
+		c := New()
+		assert.NilError(t, c.ProvideFromConfig(Config{Addr: ":8080"}))
+
+		val, err := c.resolve(reflect.TypeOf(""), nil)
+		assert.NilError(t, err)
+		assert.Equal(t, val.Interface(), ":8080")
+	})
+
+	t.Run("Reports conflicting fields", func(t *testing.T) {
+		c := New()
+		c.Provide(func() string { return "" })
+
+		err := c.ProvideFromConfig(&Config{})
+		assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "string"})
+		assert.DeepEqual(t, typeNames(c.Types()), []string{"string", "time.Duration"})
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		c := New()
+
+		assert.ErrorIs(t, c.ProvideFromConfig(42), errs.NotAStructError{TypeName: "int"})
+		assert.ErrorIs(t, c.ProvideFromConfig((*Config)(nil)), errs.NilValueError{})
+		assert.ErrorIs(t, c.ProvideFromConfig(nil), errs.NilValueError{})
+	})
+}
+
+func TestProvideSections(t *testing.T) {
+	t.Parallel()
+
+	type DBConfig struct {
+		DSN string
+	}
+
+	type HTTPConfig struct {
+		Addr string
+	}
+
+	type AppConfig struct {
+		DB      DBConfig
+		HTTP    *HTTPConfig
+		Name    string
+		logging struct{ Level string }
+	}
+
+	t.Run("Provides each section", func(t *testing.T) {
+		c := New()
+		cfg := &AppConfig{DB: DBConfig{DSN: "postgres://"}, HTTP: &HTTPConfig{Addr: ":8080"}, Name: "app"}
+
+		assert.NilError(t, c.ProvideSections(cfg))
+		assert.DeepEqual(t, typeNames(c.Types()), []string{"*zeus.HTTPConfig", "zeus.DBConfig"})
+
+		db, err := c.resolve(reflect.TypeOf(DBConfig{}), nil)
+		assert.NilError(t, err)
+		assert.Equal(t, db.Interface(), DBConfig{DSN: "postgres://"})
+
+		http, err := c.resolve(reflect.TypeOf(&HTTPConfig{}), nil)
+		assert.NilError(t, err)
+		assert.Equal(t, http.Interface(), cfg.HTTP)
+	})
+
+	t.Run("Reports sections sharing a type", func(t *testing.T) {
+		type Config struct {
+			Primary DBConfig
+			Replica DBConfig
+			HTTP    HTTPConfig
+		}
+
+		c := New()
+
+		err := c.ProvideSections(Config{})
+		assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "zeus.DBConfig"})
+		assert.Equal(t, len(c.Types()), 0)
+	})
+
+	t.Run("Reports pointer sections sharing a type", func(t *testing.T) {
+		type Config struct {
+			Public  *HTTPConfig
+			Private *HTTPConfig
+		}
+
+		c := New()
+
+		err := c.ProvideSections(Config{Public: &HTTPConfig{}, Private: &HTTPConfig{}})
+		assert.ErrorIs(t, err, errs.FactoryAlreadyProvidedError{TypeName: "*zeus.HTTPConfig"})
+		assert.Equal(t, len(c.Types()), 0)
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		c := New()
+
+		assert.ErrorIs(t, c.ProvideSections(42), errs.NotAStructError{TypeName: "int"})
+		assert.ErrorIs(t, c.ProvideSections((*AppConfig)(nil)), errs.NilValueError{})
+	})
+}
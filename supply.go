@@ -0,0 +1,129 @@
+package zeus
+
+import (
+	"reflect"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// Supply registers ready-made values, each provided as its own dynamic type.
+// It is a shorthand for providing a factory returning the value, for literals such as configuration.
+// Supplied values are kept by ResetComputed. Returns an error if a value is nil
+// or if its type is already provided.
+//
+// Example:
+//
+//	c := zeus.New()
+//	c.Supply(Config{Addr: ":8080"}, slog.Default())
+func (c *Container) Supply(values ...interface{}) error {
+	targets := []reflect.Type{}
+
+	for _, value := range values {
+		if value != nil {
+			targets = append(targets, reflect.TypeOf(value))
+		}
+	}
+
+	stale, err := c.stale(targets)
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, value := range values {
+		if value == nil {
+			return errs.NilValueError{}
+		}
+
+		supplied := reflect.ValueOf(value)
+
+		if err := c.supply(supplied.Type(), supplied); err != nil {
+			return err
+		}
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// SupplyTyped registers a ready-made value under the type T, like Supply, instead of its dynamic type.
+// This spells out the type of primitives and lets values be supplied as an interface, or as nil.
+// Returns an error if T is already provided, so supplying two ints collides instead of replacing one with the other.
+//
+// Example:
+//
+//	zeus.SupplyTyped[Port](c, 8080)
+//	zeus.SupplyTyped[io.Writer](c, os.Stdout)
+func SupplyTyped[T any](c *Container, value T) error {
+	t := typeOf[T]()
+	stale, err := c.stale([]reflect.Type{t})
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.supply(t, reflect.ValueOf(&value).Elem()); err != nil {
+		return err
+	}
+
+	c.invalidate(stale)
+
+	return nil
+}
+
+// supply registers a factory returning the given value under the given type. The caller must hold the lock for writing.
+func (c *Container) supply(t reflect.Type, value reflect.Value) error {
+	if _, exists := c.providers[t]; exists {
+		return errs.FactoryAlreadyProvidedError{TypeName: t.Name()}
+	}
+
+	factoryType := reflect.FuncOf(nil, []reflect.Type{t}, false)
+	c.providers[t] = reflect.MakeFunc(factoryType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{value}
+	})
+	c.supplied[t] = true
+	c.registered = append(c.registered, t)
+
+	return nil
+}
+
+// ResetComputed discards every value built from a factory, so that it is rebuilt on its next resolution,
+// while keeping the values registered with Supply. This lets a changed configuration be picked up in place.
+// The hooks registered while building the discarded values are dropped without running,
+// so stop the container before resetting it. Functions registered with ProvideLifecycle are called again by the next run.
+//
+// Example:
+//
+//	c.ResetComputed()
+//	c.Run(func(s *Server) { /* built again from the current configuration */ })
+func (c *Container) ResetComputed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.heldTypes() {
+		if !c.supplied[t] {
+			c.uncache(t)
+		}
+	}
+
+	clear(c.tuples)
+	c.records = nil
+
+	// The hooks of the lifecycle functions were dropped with the records, so they are called again.
+	for i := range c.lifecycles {
+		c.lifecycles[i].invoked = false
+	}
+
+	for _, members := range c.groups {
+		for _, member := range members {
+			member.value = reflect.Value{}
+		}
+	}
+}
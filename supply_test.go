@@ -0,0 +1,110 @@
+package zeus
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestSupply(t *testing.T) {
+	t.Parallel()
+
+	type Config struct{ Addr string }
+	type Server struct{ Addr string }
+
+	t.Run("Supply", func(t *testing.T) {
+		t.Run("Supplied values are resolvable", func(t *testing.T) {
+			c := New()
+			err := c.Supply(Config{Addr: ":8080"}, 42)
+			assert.NilError(t, err)
+
+			err = c.Run(func(cfg Config, i int) {
+				assert.Equal(t, cfg.Addr, ":8080")
+				assert.Equal(t, i, 42)
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Nil value", func(t *testing.T) {
+			c := New()
+			got := c.Supply(nil)
+
+			assert.ErrorIs(t, got, errs.NilValueError{})
+		})
+
+		t.Run("Already provided", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 0 })
+			got := c.Supply(42)
+
+			assert.ErrorIs(t, got, errs.FactoryAlreadyProvidedError{TypeName: "int"})
+		})
+	})
+
+	t.Run("SupplyTyped", func(t *testing.T) {
+		type Port int
+
+		t.Run("Typed values are resolvable", func(t *testing.T) {
+			c := New()
+			assert.NilError(t, SupplyTyped[Port](c, 8080))
+			assert.NilError(t, SupplyTyped(c, "app"))
+			assert.NilError(t, SupplyTyped(c, 30*time.Second))
+			assert.NilError(t, SupplyTyped[fmt.Stringer](c, nil))
+
+			err := c.Run(func(port Port, name string, timeout time.Duration, s fmt.Stringer) {
+				assert.Equal(t, port, Port(8080))
+				assert.Equal(t, name, "app")
+				assert.Equal(t, timeout, 30*time.Second)
+				assert.Assert(t, s == nil)
+			})
+			assert.NilError(t, err)
+		})
+
+		t.Run("Two ints collide", func(t *testing.T) {
+			c := New()
+			assert.NilError(t, SupplyTyped(c, 8080))
+			got := SupplyTyped(c, 9090)
+
+			assert.ErrorIs(t, got, errs.FactoryAlreadyProvidedError{TypeName: "int"})
+		})
+
+		t.Run("Kept by ResetComputed", func(t *testing.T) {
+			c := New()
+			SupplyTyped[Port](c, 8080)
+			c.Run(func(Port) {})
+			c.ResetComputed()
+
+			assert.DeepEqual(t, typeNames(c.CachedTypes()), []string{"zeus.Port"})
+		})
+	})
+
+	t.Run("ResetComputed", func(t *testing.T) {
+		c := New()
+		config := &Config{Addr: ":8080"}
+		builds := 0
+
+		c.Supply(config)
+		c.Provide(func(cfg *Config) *Server {
+			builds++
+			return &Server{Addr: cfg.Addr}
+		})
+
+		var first *Server
+		c.Run(func(s *Server) { first = s })
+
+		config.Addr = ":9090"
+		c.ResetComputed()
+
+		err := c.Run(func(cfg *Config, s *Server) {
+			assert.Equal(t, cfg, config)
+			assert.Assert(t, s != first)
+			assert.Equal(t, s.Addr, ":9090")
+		})
+
+		assert.NilError(t, err)
+		assert.Equal(t, builds, 2)
+	})
+}
@@ -0,0 +1,101 @@
+package zeus
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Timings reports how long each phase of a Run took.
+// Phases the run did not reach, such as the start and stop hooks of a run managed by Build, are left at zero.
+type Timings struct {
+	Resolve  time.Duration
+	Start    time.Duration
+	Function time.Duration
+	Stop     time.Duration
+}
+
+// timingLog keeps the timings of the last run of a container.
+// It is shared by a container and the overlays derived from it, so runs with overrides are reported too.
+type timingLog struct {
+	mu   sync.Mutex
+	last Timings
+}
+
+// LastRunTimings returns the timings of the last Run, or zero timings if the container does not record them.
+// Runs only record their timings with the RecordTimings option.
+//
+// Example:
+//
+//	c := zeus.New(zeus.RecordTimings())
+//	c.Run(serve)
+//	fmt.Println(c.LastRunTimings().Start)
+func (c *Container) LastRunTimings() Timings {
+	if c.timings == nil {
+		return Timings{}
+	}
+
+	c.timings.mu.Lock()
+	defer c.timings.mu.Unlock()
+
+	return c.timings.last
+}
+
+// StartupDuration returns how long the last startup took: for a Run, from its call until its function began,
+// covering the resolution of its parameters and the start hooks, and for Build, until the start hooks completed.
+// Runs of a container started with Build keep the duration of Build. Returns zero before the first startup.
+//
+// Example:
+//
+//	c.Run(func(logger *slog.Logger) {
+//	    logger.Info("started", "in", c.StartupDuration())
+//	})
+func (c *Container) StartupDuration() time.Duration {
+	return time.Duration(c.startup.Load())
+}
+
+// recordStartup keeps the time elapsed since the given instant as the last startup duration.
+func recordStartup(startup *atomic.Int64, since time.Time) {
+	startup.Store(int64(time.Since(since)))
+}
+
+// stopwatch times the phases of a run. A nil stopwatch, handed out when timings are not recorded, does nothing.
+type stopwatch struct {
+	log   *timingLog
+	since time.Time
+}
+
+// stopwatch returns a stopwatch recording into the timings of the container, or nil if they are not recorded.
+func (c *Container) stopwatch() *stopwatch {
+	if c.timings == nil {
+		return nil
+	}
+
+	return &stopwatch{log: c.timings}
+}
+
+// begin marks the start of a phase.
+func (s *stopwatch) begin() {
+	if s != nil {
+		s.since = time.Now()
+	}
+}
+
+// end stores the time elapsed since the start of the phase in the given duration.
+func (s *stopwatch) end(phase *time.Duration) {
+	if s != nil {
+		*phase = time.Since(s.since)
+	}
+}
+
+// record keeps the given timings as those of the last run.
+func (s *stopwatch) record(timings *Timings) {
+	if s == nil {
+		return
+	}
+
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+
+	s.log.last = *timings
+}
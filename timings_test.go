@@ -0,0 +1,108 @@
+package zeus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLastRunTimings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Every phase is timed", func(t *testing.T) {
+		c := New(RecordTimings())
+		c.Provide(func(h Hooks) string {
+			time.Sleep(5 * time.Millisecond)
+			h.OnStart(func() error { time.Sleep(20 * time.Millisecond); return nil })
+			h.OnStop(func() error { time.Sleep(60 * time.Millisecond); return nil })
+			return "value"
+		})
+
+		err := c.Run(func(s string) { time.Sleep(40 * time.Millisecond) })
+		assert.NilError(t, err)
+
+		timings := c.LastRunTimings()
+		assert.Assert(t, timings.Resolve >= 5*time.Millisecond)
+		assert.Assert(t, timings.Start >= 20*time.Millisecond)
+		assert.Assert(t, timings.Function >= 40*time.Millisecond)
+		assert.Assert(t, timings.Stop >= 60*time.Millisecond)
+		assert.Assert(t, timings.Resolve < timings.Start)
+		assert.Assert(t, timings.Start < timings.Function)
+		assert.Assert(t, timings.Function < timings.Stop)
+	})
+
+	t.Run("Phases not reached are zero", func(t *testing.T) {
+		c := New(RecordTimings())
+		c.Provide(func(h Hooks) string {
+			h.OnStart(func() error { return errors.New("start error") })
+			return "value"
+		})
+
+		err := c.Run(func(s string) {})
+		assert.ErrorContains(t, err, "start error")
+
+		timings := c.LastRunTimings()
+		assert.Equal(t, timings.Function, time.Duration(0))
+		assert.Equal(t, timings.Stop, time.Duration(0))
+	})
+
+	t.Run("Runs with overrides are timed", func(t *testing.T) {
+		c := New(RecordTimings())
+		c.Provide(func() string { return "real" })
+
+		err := c.Run(func(s string) { time.Sleep(5 * time.Millisecond) }, OverrideFor(func() string { return "fake" }))
+		assert.NilError(t, err)
+		assert.Assert(t, c.LastRunTimings().Function >= 5*time.Millisecond)
+	})
+
+	t.Run("Timings are not recorded without the option", func(t *testing.T) {
+		c := New()
+
+		err := c.Run(func() { time.Sleep(time.Millisecond) })
+		assert.NilError(t, err)
+		assert.Equal(t, c.LastRunTimings(), Timings{})
+	})
+}
+
+func TestStartupDuration(t *testing.T) {
+	t.Parallel()
+
+	newContainer := func() *Container {
+		c := New()
+		c.Provide(func(h Hooks) string {
+			h.OnStart(func() error { time.Sleep(20 * time.Millisecond); return nil })
+			return "value"
+		})
+		return c
+	}
+
+	t.Run("Run measures its startup", func(t *testing.T) {
+		c := newContainer()
+		assert.Equal(t, c.StartupDuration(), time.Duration(0))
+
+		var during time.Duration
+		err := c.Run(func(s string) {
+			during = c.StartupDuration()
+			time.Sleep(10 * time.Millisecond)
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, during >= 20*time.Millisecond, during)
+		assert.Assert(t, during < 20*time.Millisecond+time.Second, during)
+		assert.Equal(t, c.StartupDuration(), during)
+	})
+
+	t.Run("Build measures its startup", func(t *testing.T) {
+		c := newContainer()
+
+		assert.NilError(t, c.Build())
+		defer c.Close()
+
+		startup := c.StartupDuration()
+		assert.Assert(t, startup >= 20*time.Millisecond, startup)
+
+		assert.NilError(t, c.Run(func(s string) {}))
+		assert.Equal(t, c.StartupDuration(), startup)
+	})
+}
@@ -0,0 +1,85 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Trace records the resolutions made during a single run, in the order they completed.
+type Trace struct {
+	Entries []TraceEntry
+}
+
+// TraceEntry describes the resolution of a type.
+// Built types report how long their construction took, including their dependencies, and the error if it failed.
+// Cached is set when the value was already available, or built by another caller, and no factory ran.
+type TraceEntry struct {
+	Type     reflect.Type
+	Duration time.Duration
+	Cached   bool
+	Err      error
+}
+
+// Types returns the type of every entry in the trace, in order.
+func (t Trace) Types() []reflect.Type {
+	types := make([]reflect.Type, len(t.Entries))
+
+	for i, entry := range t.Entries {
+		types[i] = entry.Type
+	}
+
+	return types
+}
+
+// tracer collects the entries of a RunTrace while it is in progress.
+type tracer struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+}
+
+// RunTrace executes the provided function like Run and returns the trace of the resolutions it made.
+// Dependencies are recorded as they are built, before the types depending on them, and types already
+// in the container are recorded as cache hits. Resolutions made concurrently on the container
+// while the function runs are recorded as well.
+//
+// Example:
+//
+//	trace, err := c.RunTrace(serve)
+//	for _, entry := range trace.Entries {
+//	    fmt.Println(entry.Type, entry.Duration, entry.Cached)
+//	}
+func (c *Container) RunTrace(fn interface{}) (Trace, error) {
+	recorder := new(tracer)
+
+	c.mu.Lock()
+	c.tracers = append(c.tracers, recorder)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.tracers = slices.DeleteFunc(c.tracers, func(t *tracer) bool { return t == recorder })
+		c.mu.Unlock()
+	}()
+
+	err := c.Run(fn)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return Trace{Entries: slices.Clone(recorder.entries)}, err
+}
+
+// trace adds an entry to every RunTrace in progress.
+func (c *Container) trace(entry TraceEntry) {
+	c.mu.RLock()
+	tracers := slices.Clone(c.tracers)
+	c.mu.RUnlock()
+
+	for _, recorder := range tracers {
+		recorder.mu.Lock()
+		recorder.entries = append(recorder.entries, entry)
+		recorder.mu.Unlock()
+	}
+}
@@ -0,0 +1,79 @@
+package zeus
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRunTrace(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{ Name string }
+
+	newContainer := func() *Container {
+		c := New()
+		c.Provide(func() int { return 42 })
+		c.Provide(func(i int) string {
+			time.Sleep(time.Millisecond)
+			return fmt.Sprint(i)
+		})
+		c.Provide(func(s string) *Service { return &Service{Name: s} })
+		return c
+	}
+
+	t.Run("Lists types in construction order", func(t *testing.T) {
+		c := newContainer()
+
+		trace, err := c.RunTrace(func(s *Service) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, typeNames(trace.Types()), []string{"int", "string", "*zeus.Service"})
+
+		for _, entry := range trace.Entries {
+			assert.Assert(t, !entry.Cached)
+		}
+
+		assert.Assert(t, trace.Entries[1].Duration >= time.Millisecond)
+		assert.Assert(t, trace.Entries[2].Duration >= trace.Entries[1].Duration)
+	})
+
+	t.Run("Records cache hits", func(t *testing.T) {
+		c := newContainer()
+		c.Run(func(s string) {})
+
+		trace, err := c.RunTrace(func(s *Service, i int) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, typeNames(trace.Types()), []string{"string", "*zeus.Service", "int"})
+		assert.Assert(t, trace.Entries[0].Cached)
+		assert.Assert(t, !trace.Entries[1].Cached)
+		assert.Assert(t, trace.Entries[2].Cached)
+	})
+
+	t.Run("Scoped to the run", func(t *testing.T) {
+		c := newContainer()
+
+		_, err := c.RunTrace(func(s *Service) {})
+		assert.NilError(t, err)
+
+		trace, err := c.RunTrace(func(i int) {})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, typeNames(trace.Types()), []string{"int"})
+
+		c.Run(func(s string) {})
+		assert.DeepEqual(t, typeNames(trace.Types()), []string{"int"})
+	})
+
+	t.Run("Records failed constructions", func(t *testing.T) {
+		c := New()
+		failure := errors.New("failure")
+		c.Provide(func() (int, error) { return 0, failure })
+
+		trace, err := c.RunTrace(func(i int) {})
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, len(trace.Entries), 1)
+		assert.ErrorIs(t, trace.Entries[0].Err, failure)
+	})
+}
@@ -0,0 +1,234 @@
+package zeus
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/otoru/zeus/errs"
+)
+
+// IssueKind identifies the problem reported by a ValidationIssue.
+type IssueKind int
+
+const (
+	// IssueMissing reports a dependency that nothing can build.
+	IssueMissing IssueKind = iota
+	// IssueCyclic reports types that depend on each other.
+	IssueCyclic
+	// IssueAmbiguous reports a dependency satisfied by several candidates.
+	IssueAmbiguous
+)
+
+// String returns a readable name for the issue kind.
+func (k IssueKind) String() string {
+	switch k {
+	case IssueMissing:
+		return "missing"
+	case IssueCyclic:
+		return "cyclic"
+	case IssueAmbiguous:
+		return "ambiguous"
+	default:
+		return "unknown"
+	}
+}
+
+// ValidationIssue describes a problem found in the dependency graph without building anything.
+// Types lists the involved types: the missing or ambiguous type followed by the type needing it,
+// or the types forming the cycle. Err is the error resolving the types would fail with.
+type ValidationIssue struct {
+	Kind    IssueKind
+	Types   []reflect.Type
+	Message string
+	Err     error
+}
+
+// ValidationIssues inspects the registered factories and returns every problem found, in a stable order.
+// Nothing is built: missing dependencies, cycles and ambiguous interfaces are found from the static graph.
+//
+// Example:
+//
+//	for _, issue := range c.ValidationIssues() {
+//	    fmt.Printf("%s: %s\n", issue.Kind, issue.Message)
+//	}
+func (c *Container) ValidationIssues() []ValidationIssue {
+	issues := []ValidationIssue{}
+	cyclic := map[reflect.Type]bool{}
+
+	for _, t := range c.Types() {
+		if issue, ok := c.cycleIssue(t, cyclic); ok {
+			issues = append(issues, issue)
+		}
+
+		for _, dependency := range sortTypes(append(c.dependenciesOf(t), c.predecessors(t)...)) {
+			if issue, ok := c.checkDependency(dependency); !ok {
+				issue.Types = []reflect.Type{dependency, t}
+				issue.Message = fmt.Sprintf("%s, needed by %s", issue.Err, t)
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues
+}
+
+// Validate inspects the registered factories like ValidationIssues and
+// returns the error of every issue found, aggregated in an ErrorSet, or nil if there is none.
+//
+// Example:
+//
+//	if err := c.Validate(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Container) Validate() error {
+	errorSet := &errs.ErrorSet{}
+
+	for _, issue := range c.ValidationIssues() {
+		errorSet.Add(issue.Err)
+	}
+
+	return errorSet.Result()
+}
+
+// checkDependency reports whether the given type can be built, statically.
+// If not, it returns an issue carrying the kind and error of the problem.
+// Types nothing is registered for are checked against the fallback resolver, if any, discarding its value.
+func (c *Container) checkDependency(t reflect.Type) (ValidationIssue, bool) {
+	issue, ok := c.checkRegistrations(t)
+
+	if ok || issue.Kind != IssueMissing || c.isDisabled(t) {
+		return issue, ok
+	}
+
+	if _, handled, err := c.resolveFallback(t, newTypeStack([]reflect.Type{t})); handled && err == nil {
+		return ValidationIssue{}, true
+	}
+
+	return issue, false
+}
+
+// checkRegistrations reports whether the given type can be built from what is registered in the container,
+// as described in checkDependency, leaving out the fallback resolver.
+func (c *Container) checkRegistrations(t reflect.Type) (ValidationIssue, bool) {
+	if isSupplied(t) || t.Implements(injectorType) {
+		return ValidationIssue{}, true
+	}
+
+	if _, ok := c.keyedProviders(t); ok {
+		return ValidationIssue{}, true
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.disabled[t] {
+		return ValidationIssue{Kind: IssueMissing, Err: errs.DependencyResolutionError{TypeName: t.Name()}}, false
+	}
+
+	_, hasProvider := c.providers[t]
+	_, hasInstance := c.instances[t]
+	_, aliased := c.aliasOf(t)
+
+	if hasProvider || hasInstance || aliased {
+		return ValidationIssue{}, true
+	}
+
+	bindings := slices.DeleteFunc(slices.Clone(c.bindings[t]), func(concrete reflect.Type) bool {
+		return c.disabled[concrete]
+	})
+	sources := make([]reflect.Type, len(c.converters[t]))
+
+	for i, converter := range c.converters[t] {
+		sources[i] = converter.Type().In(0)
+	}
+
+	implementations := c.implementations(t)
+
+	switch {
+	case len(bindings) == 1:
+		return ValidationIssue{}, true
+	case len(bindings) > 1:
+		return ValidationIssue{Kind: IssueAmbiguous, Err: ambiguous(t, bindings)}, false
+	case len(sources) == 1:
+		return ValidationIssue{}, true
+	case len(sources) > 1:
+		return ValidationIssue{Kind: IssueAmbiguous, Err: ambiguous(t, sources)}, false
+	case len(implementations) == 1:
+		return ValidationIssue{}, true
+	case len(implementations) > 1:
+		return ValidationIssue{Kind: IssueAmbiguous, Err: ambiguous(t, implementations)}, false
+	case c.collected(t) != nil, c.nilDefaults[t]:
+		return ValidationIssue{}, true
+	}
+
+	return ValidationIssue{Kind: IssueMissing, Err: errs.DependencyResolutionError{TypeName: t.Name()}}, false
+}
+
+// cycleIssue returns the issue of the cycle the given type belongs to, unless there is none or it was reported already.
+// The members of the cycle are marked in cyclic, so each cycle is reported once.
+func (c *Container) cycleIssue(t reflect.Type, cyclic map[reflect.Type]bool) (ValidationIssue, bool) {
+	cycle := c.findCycle(t)
+	if cycle == nil || cyclic[cycle[0]] {
+		return ValidationIssue{}, false
+	}
+
+	for _, member := range cycle {
+		cyclic[member] = true
+	}
+
+	err := errs.CyclicDependencyError{TypeName: cycle[0].Name()}
+
+	return ValidationIssue{
+		Kind:    IssueCyclic,
+		Types:   cycle,
+		Message: fmt.Sprintf("%s through %v", err, cycle),
+		Err:     err,
+	}, true
+}
+
+// functionIssues returns the problems preventing the dependencies of the given function from being resolved,
+// found from the static graph like ValidationIssues. Only the types the function needs are inspected.
+// Dependencies resolved on demand, such as Provider[T], are not followed, since they may never be requested.
+func (c *Container) functionIssues(fnType reflect.Type) []ValidationIssue {
+	issues := []ValidationIssue{}
+	visited := map[reflect.Type]bool{}
+	cyclic := map[reflect.Type]bool{}
+
+	var walk func(t reflect.Type, dependent string)
+
+	walk = func(t reflect.Type, dependent string) {
+		issue, ok := c.checkDependency(t)
+
+		if !ok {
+			issue.Types = []reflect.Type{t}
+			issue.Message = fmt.Sprintf("%s, needed by %s", issue.Err, dependent)
+			issues = append(issues, issue)
+			return
+		}
+
+		if visited[t] || isSupplied(t) || t.Implements(injectorType) {
+			return
+		}
+
+		visited[t] = true
+
+		if issue, ok := c.cycleIssue(t, cyclic); ok {
+			issues = append(issues, issue)
+		}
+
+		if cyclic[t] {
+			return
+		}
+
+		for _, dependency := range sortTypes(append(c.dependenciesOf(t), c.predecessors(t)...)) {
+			walk(dependency, t.String())
+		}
+	}
+
+	for i := 0; i < fnType.NumIn(); i++ {
+		walk(fnType.In(i), "the function")
+	}
+
+	return issues
+}
@@ -0,0 +1,81 @@
+package zeus
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/otoru/zeus/errs"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ValidationIssues", func(t *testing.T) {
+		t.Run("Missing dependency and cycle", func(t *testing.T) {
+			c := New()
+			c.Provide(func(f float64) bool { return f > 0 })
+			c.Provide(func(s string) int { return len(s) })
+			c.Provide(func(i int) string { return fmt.Sprint(i) })
+
+			issues := c.ValidationIssues()
+			assert.Equal(t, len(issues), 2)
+
+			assert.Equal(t, issues[0].Kind, IssueMissing)
+			assert.DeepEqual(t, typeNames(issues[0].Types), []string{"float64", "bool"})
+			assert.ErrorIs(t, issues[0].Err, errs.DependencyResolutionError{TypeName: "float64"})
+			assert.Equal(t, issues[0].Message, "failed to resolve dependency for type float64, needed by bool")
+
+			assert.Equal(t, issues[1].Kind, IssueCyclic)
+			assert.DeepEqual(t, typeNames(issues[1].Types), []string{"int", "string"})
+			assert.ErrorIs(t, issues[1].Err, errs.CyclicDependencyError{TypeName: "int"})
+		})
+
+		t.Run("Ambiguous interface", func(t *testing.T) {
+			c := New()
+			c.ProvideAs(func() *strings.Builder { return &strings.Builder{} }, new(fmt.Stringer))
+			c.ProvideAs(func() *net.IPNet { return &net.IPNet{} }, new(fmt.Stringer))
+			c.Provide(func(s fmt.Stringer) int { return 0 })
+
+			issues := c.ValidationIssues()
+			assert.Equal(t, len(issues), 1)
+			assert.Equal(t, issues[0].Kind, IssueAmbiguous)
+		})
+
+		t.Run("Valid graph", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+			c.Provide(func(i int, h Hooks, p Provider[bool]) string { return "Hello" })
+
+			assert.Equal(t, len(c.ValidationIssues()), 0)
+		})
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		t.Run("Aggregates the issues", func(t *testing.T) {
+			c := New()
+			c.Provide(func(f float64) bool { return f > 0 })
+			c.Provide(func(s string) int { return len(s) })
+			c.Provide(func(i int) string { return fmt.Sprint(i) })
+
+			err := c.Validate()
+			assert.ErrorContains(t, err, "failed to resolve dependency for type float64")
+			assert.ErrorContains(t, err, "cyclic dependency detected for type int")
+		})
+
+		t.Run("Valid graph", func(t *testing.T) {
+			c := New()
+			c.Provide(func() int { return 42 })
+
+			assert.NilError(t, c.Validate())
+		})
+	})
+
+	t.Run("IssueKind", func(t *testing.T) {
+		assert.Equal(t, IssueMissing.String(), "missing")
+		assert.Equal(t, IssueCyclic.String(), "cyclic")
+		assert.Equal(t, IssueAmbiguous.String(), "ambiguous")
+	})
+}
@@ -0,0 +1,60 @@
+package zeus
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var warningsType = reflect.TypeOf((*Warnings)(nil)).Elem()
+
+// Warnings lets a factory report non-fatal problems met while building its value.
+// Like Hooks, it is supplied by the container to any factory declaring a parameter of this type.
+// Reporting a warning does not fail the construction: use the error result of the factory for that.
+//
+// Example:
+//
+//	c.Provide(func(w zeus.Warnings) *Config {
+//	    if os.Getenv("PORT") == "" {
+//	        w.Warn(errors.New("PORT is not set, using 8080"))
+//	    }
+//	    return loadConfig()
+//	})
+type Warnings interface {
+	Warn(err error)
+}
+
+// warningLog collects the warnings reported by factories.
+// It is shared by a container and the overlays derived from it, so warnings reported during a Run with overrides are kept.
+type warningLog struct {
+	mu       sync.Mutex
+	warnings []error
+}
+
+// Warn records the given warning. Nil errors are ignored.
+func (l *warningLog) Warn(err error) {
+	if err == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.warnings = append(l.warnings, err)
+}
+
+// Warnings returns the warnings reported by factories through Warnings so far, in the order they were reported.
+// Warnings are kept for the lifetime of the container, even if the construction reporting them later failed.
+//
+// Example:
+//
+//	err := c.Run(serve)
+//	for _, warning := range c.Warnings() {
+//	    log.Println("warning:", warning)
+//	}
+func (c *Container) Warnings() []error {
+	c.warnings.mu.Lock()
+	defer c.warnings.mu.Unlock()
+
+	return slices.Clone(c.warnings.warnings)
+}
@@ -0,0 +1,76 @@
+package zeus
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Warnings are kept without failing the run", func(t *testing.T) {
+		c := New()
+		warning := errors.New("using default port")
+		c.Provide(func(w Warnings) int {
+			w.Warn(warning)
+			w.Warn(nil)
+			return 8080
+		})
+
+		var port int
+		err := c.Run(func(p int) { port = p })
+		assert.NilError(t, err)
+		assert.Equal(t, port, 8080)
+		assert.DeepEqual(t, warningMessages(c), []string{warning.Error()})
+	})
+
+	t.Run("Warnings are reported in order", func(t *testing.T) {
+		c := New()
+		first, second := errors.New("first"), errors.New("second")
+		c.Provide(func(w Warnings) string {
+			w.Warn(first)
+			return ""
+		})
+		c.Provide(func(w Warnings, s string) int {
+			w.Warn(second)
+			return 0
+		})
+
+		assert.NilError(t, c.Run(func(int) {}))
+		assert.DeepEqual(t, warningMessages(c), []string{"first", "second"})
+	})
+
+	t.Run("Warnings reported with overrides are kept", func(t *testing.T) {
+		c := New()
+		warning := errors.New("overridden")
+		c.Provide(func() int { return 0 })
+
+		err := c.Run(func(int) {}, OverrideFor(func(w Warnings) int {
+			w.Warn(warning)
+			return 1
+		}))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, warningMessages(c), []string{warning.Error()})
+	})
+
+	t.Run("Warnings are not a dependency", func(t *testing.T) {
+		c := New()
+		c.Provide(func(w Warnings) int { return 0 })
+
+		assert.NilError(t, c.Validate())
+		assert.Equal(t, len(c.Warnings()), 0)
+	})
+}
+
+// warningMessages returns the messages of the warnings kept by the container, in order.
+func warningMessages(c *Container) []string {
+	messages := []string{}
+
+	for _, warning := range c.Warnings() {
+		messages = append(messages, warning.Error())
+	}
+
+	return messages
+}